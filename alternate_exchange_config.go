@@ -0,0 +1,41 @@
+package amqp
+
+// AlternateExchangeConfig represents the alternate exchange topology that is declared along with an exchange.
+//
+// When an exchange has an alternate exchange, the messages published on it that can not be routed to any queue
+// are routed to the alternate exchange instead of being discarded.
+// The library declares a fanout alternate exchange and a capture queue bound to it, so the unroutable messages are retained.
+type AlternateExchangeConfig struct {
+	// ExchangeName is the name of the alternate exchange.
+	//
+	// default: "<exchange name>.ae"
+	ExchangeName string
+
+	// QueueName is the name of the queue that captures the unroutable messages.
+	//
+	// default: "<exchange name>.unroutable"
+	QueueName string
+
+	// Queue is the configuration used to declare the capture queue.
+	//
+	// default: a queue with the same durability as the exchange
+	Queue *QueueBindConfig
+}
+
+// exchangeName returns the alternate exchange name for the main exchange
+func (c AlternateExchangeConfig) exchangeName(exchangeName string) string {
+	if c.ExchangeName != "" {
+		return c.ExchangeName
+	}
+
+	return exchangeName + ".ae"
+}
+
+// queueName returns the capture queue name for the main exchange
+func (c AlternateExchangeConfig) queueName(exchangeName string) string {
+	if c.QueueName != "" {
+		return c.QueueName
+	}
+
+	return exchangeName + ".unroutable"
+}
@@ -1,8 +1,7 @@
 package amqp
 
 import (
-	"errors"
-	"fmt"
+	"sync"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -10,25 +9,118 @@ import (
 // client represents the client with connection to AMQP.
 type client struct {
 	conn *amqp.Connection
+
+	// config its the configuration used to connect the client
+	config Config
+
+	// middlewares are the middlewares that wrap the message handling of every queue consumed through the client
+	middlewares []Middleware
+
+	// interceptors are the interceptors that wrap the message publishing of every publisher created through the client
+	interceptors []PublishInterceptor
+
+	// tracer traces the message publishing and handling, or is nil if the client does not trace them
+	tracer *tracer
+
+	// health keeps the exchanges, publishers and consumers reported by the client health
+	health healthRegistry
+
+	// blocking its the last connection blocking notification sent by the server
+	blocking amqp.Blocking
+
+	// unblocked its closed when the server unblocks the connection, or is nil while the connection is not blocked
+	unblocked  chan struct{}
+	blockingMu sync.RWMutex
+
+	// blockedChanges are the blocked state changes waiting to be notified to the OnBlockedChange function, in order
+	blockedChanges   []amqp.Blocking
+	blockedChangesMu sync.Mutex
+
+	// blockedChangeSignal signals the blocked changes dispatcher that there are changes to notify
+	blockedChangeSignal chan struct{}
 }
 
 // NewClient connects to the AMQP server using the provided configuration, and returns the AMQP Client.
 func NewClient(URL string, conf ...Config) (c Client, err error) {
+	config := Config{}
 	amqpConfig := amqp.Config{}
 	if len(conf) > 0 {
-		amqpConfig = conf[0].toAMQPConfig()
+		config = conf[0]
+		amqpConfig = config.toAMQPConfig()
 	}
 
 	conn, err := amqp.DialConfig(URL, amqpConfig)
 	if err != nil {
-		err = fmt.Errorf("Error when connecting to AMQP, %v", err)
+		err = newError(err, "Error when connecting to AMQP")
 		return
 	}
 
-	c = &client{conn}
+	cl := &client{
+		conn:   conn,
+		config: config,
+		tracer: newTracer(config.Tracing),
+	}
+	cl.watchConnection()
+
+	c = cl
 	return
 }
 
+// watchConnection reports the connection state to the client metrics and logger, when the connection is opened and when it is closed
+func (c *client) watchConnection() {
+	c.metrics().ConnectionStateChanged(true)
+	c.logger().Info("AMQP connection opened")
+
+	c.blockedChangeSignal = make(chan struct{}, 1)
+	go c.dispatchBlockedChanges()
+
+	blockings := c.conn.NotifyBlocked(make(chan amqp.Blocking, 1))
+	go func() {
+		for b := range blockings {
+			c.setBlocking(b)
+		}
+
+		close(c.blockedChangeSignal)
+	}()
+
+	closes := c.conn.NotifyClose(make(chan *amqp.Error, 1))
+	go func() {
+		closeErr, ok := <-closes
+		if ok && closeErr != nil {
+			c.logger().Error("AMQP connection closed by the server", "error", closeErr)
+		} else {
+			c.logger().Info("AMQP connection closed")
+		}
+
+		c.metrics().ConnectionStateChanged(false)
+	}()
+}
+
+// metrics returns the client metrics collector, or a collector that discards the metrics if the client has none
+func (c *client) metrics() Metrics {
+	if c == nil || c.config.Metrics == nil {
+		return noopMetrics{}
+	}
+
+	return c.config.Metrics
+}
+
+// withAdminChannel calls the function with a short-lived channel, closed after the function returns,
+// so the server closing the channel on an error (i.e. when a queue is not found) does not affect the long-lived channels
+func (c *client) withAdminChannel(f func(ch *amqp.Channel) error) error {
+	if c == nil || c.conn == nil {
+		return ErrConnectionNotOpen
+	}
+
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	return f(ch)
+}
+
 // Close will close the rabbitmq connection.
 func (c *client) Close() (err error) {
 	if c.conn != nil {
@@ -38,15 +130,25 @@ func (c *client) Close() (err error) {
 	return
 }
 
+// Use adds middlewares that will wrap the message handling of every queue consumed through the client
+func (c *client) Use(middlewares ...Middleware) {
+	c.middlewares = append(c.middlewares, middlewares...)
+}
+
+// Intercept adds interceptors that will wrap the message publishing of every publisher created through the client
+func (c *client) Intercept(interceptors ...PublishInterceptor) {
+	c.interceptors = append(c.interceptors, interceptors...)
+}
+
 // Ping checks the rabbitmq connection health
 func (c *client) Ping() (err error) {
 	if c.conn == nil {
-		err = errors.New("The AMQP connection is not open")
+		err = ErrConnectionNotOpen
 		return
 	}
 
 	if c.conn.IsClosed() {
-		err = errors.New("AMQP disconnected")
+		err = ErrConnectionClosed
 	}
 
 	return
@@ -55,13 +157,13 @@ func (c *client) Ping() (err error) {
 // StartExchange starts a amqp exchange and returns a channel with the exchange declared
 func (c *client) StartExchange(exchangeName string, exchangeType ExchangeType, conf ...ExchangeConfig) (e Exchange, err error) {
 	if c.conn == nil {
-		err = errors.New("The AMQP connection is not open")
+		err = ErrConnectionNotOpen
 		return
 	}
 
-	ch, err := c.conn.Channel()
+	ch, err := c.openChannel("exchange", exchangeName)
 	if err != nil {
-		err = fmt.Errorf("Failed to create a new channel for the %s exchange, %v", exchangeName, err)
+		err = newError(err, "Failed to create a new channel for the %s exchange", exchangeName)
 		return
 	}
 
@@ -70,6 +172,30 @@ func (c *client) StartExchange(exchangeName string, exchangeType ExchangeType, c
 		config = conf[0]
 	}
 
+	args := config.Args
+	if exchangeType == ExchangeTypeDelayed {
+		args = config.delayedArgs()
+	}
+
+	var unroutable Queue
+	if config.AlternateExchange != nil {
+		if _, ok := config.Args[alternateExchangeKey]; ok {
+			err = newConfigError(nil, "The %s exchange alternate exchange can not be defined both in the Args and in the AlternateExchange field", exchangeName)
+			return
+		}
+
+		unroutable, err = c.declareAlternateExchange(ch, exchangeName, config)
+		if err != nil {
+			return
+		}
+
+		aeArgs := Table{alternateExchangeKey: config.AlternateExchange.exchangeName(exchangeName)}
+		for k, v := range args {
+			aeArgs[k] = v
+		}
+		args = aeArgs
+	}
+
 	err = ch.ExchangeDeclare(
 		exchangeName,
 		exchangeType.ToString(),
@@ -77,34 +203,207 @@ func (c *client) StartExchange(exchangeName string, exchangeType ExchangeType, c
 		config.AutoDelete,
 		config.Internal,
 		config.NoWait,
-		config.Args.toAmqpTable(),
+		args.toAmqpTable(),
+	)
+	if err != nil {
+		err = newError(err, "Failed to declare the %s exchange", exchangeName)
+		return
+	}
+
+	ex := newExchange(c, exchangeName, exchangeType, ch)
+	ex.unroutable = unroutable
+	c.health.addExchange(ex)
+
+	e = ex
+	return
+}
+
+// declareAlternateExchange declares the alternate exchange and its capture queue for an exchange
+func (c *client) declareAlternateExchange(ch *amqp.Channel, exchangeName string, config ExchangeConfig) (q Queue, err error) {
+	aeName := config.AlternateExchange.exchangeName(exchangeName)
+	err = ch.ExchangeDeclare(
+		aeName,
+		ExchangeTypeFanout.ToString(),
+		config.Durable,
+		false,
+		false,
+		config.NoWait,
+		nil,
 	)
+	if err != nil {
+		err = newError(err, "Failed to declare the %s alternate exchange", aeName)
+		return
+	}
+
+	queueConfig := QueueBindConfig{Durable: config.Durable}
+	if config.AlternateExchange.Queue != nil {
+		queueConfig = *config.AlternateExchange.Queue
+	}
+
+	ae := newExchange(c, aeName, ExchangeTypeFanout, ch)
+	q, err = ae.BindQueue(config.AlternateExchange.queueName(exchangeName), "", queueConfig)
+	if err != nil {
+		err = newError(err, "Failed to declare the %s unroutable messages queue", config.AlternateExchange.queueName(exchangeName))
+	}
+
+	return
+}
+
+// DeclareQueue declares a queue on the default exchange and returns a channel with the queue declared
+func (c *client) DeclareQueue(queueName string, conf ...QueueBindConfig) (q Queue, err error) {
+	if c.conn == nil {
+		err = ErrConnectionNotOpen
+		return
+	}
+
+	ch, err := c.openChannel("queue", queueName)
+	if err != nil {
+		err = newError(err, "Failed to create a new channel for the %s queue", queueName)
+		return
+	}
+
+	config := QueueBindConfig{}
+	if len(conf) > 0 {
+		config = conf[0]
+	}
+
+	e := newExchange(c, defaultExchangeName, ExchangeTypeDirect, ch)
+	queue, err := e.declareQueue(queueName, queueName, config)
+	if err != nil {
+		return
+	}
+
+	// the default exchange routes the messages using the queue name as the routing-key
+	queue.routingKey = queue.name
+	q = queue
+	return
+}
+
+// Queue returns a channel with an existing queue, checking if the queue exists on the server
+func (c *client) Queue(queueName string) (q Queue, err error) {
+	if c.conn == nil {
+		err = ErrConnectionNotOpen
+		return
+	}
+
+	ch, err := c.openChannel("queue", queueName)
+	if err != nil {
+		err = newError(err, "Failed to create a new channel for the %s queue", queueName)
+		return
+	}
+
+	_, err = ch.QueueDeclarePassive(queueName, false, false, false, false, nil)
+	if err != nil {
+		err = newError(err, "Failed to find the %s queue", queueName)
+		return
+	}
 
-	e = newExchange(exchangeName, ch)
+	q = &amqpQueueBind{
+		name:       queueName,
+		routingKey: queueName,
+		exchange:   newExchange(c, defaultExchangeName, ExchangeTypeDirect, ch),
+		channel:    ch,
+	}
 	return
 }
 
 // CreatePublisher creates a new publisher to publish messages on an exchange
 func (c *client) CreatePublisher(exchangeName string, NoWait ...bool) (p Publisher, err error) {
-	waitConfirmation := true
+	config := PublisherConfig{}
+	if len(NoWait) > 0 {
+		config.NoWait = NoWait[0]
+	}
+
+	return c.CreatePublisherWithConfig(exchangeName, config)
+}
+
+// CreatePublisherWithConfig creates a new publisher to publish messages on an exchange, using the provided configuration
+func (c *client) CreatePublisherWithConfig(exchangeName string, conf PublisherConfig) (p Publisher, err error) {
+	return c.createPublisher(exchangeName, "", conf)
+}
+
+// CreateQueuePublisher creates a new publisher to publish messages directly on a queue, using the default exchange
+func (c *client) CreateQueuePublisher(queueName string, NoWait ...bool) (p Publisher, err error) {
+	config := PublisherConfig{}
+	if len(NoWait) > 0 {
+		config.NoWait = NoWait[0]
+	}
+
+	return c.CreateQueuePublisherWithConfig(queueName, config)
+}
+
+// CreateQueuePublisherWithConfig creates a new publisher to publish messages directly on a queue, using the default exchange and the provided configuration
+func (c *client) CreateQueuePublisherWithConfig(queueName string, conf PublisherConfig) (p Publisher, err error) {
+	return c.createPublisher(defaultExchangeName, queueName, conf)
+}
+
+// createPublisher creates a new publisher to publish messages on an exchange,
+// or directly on a queue using the default exchange when the queue name is not empty
+func (c *client) createPublisher(exchangeName, queueName string, conf PublisherConfig) (p Publisher, err error) {
+	targetKind, targetName := "exchange", exchangeName
+	if queueName != "" {
+		targetKind, targetName = "queue", queueName
+	}
+
+	channel, waitConfirmation, err := c.publisherChannel(targetKind, targetName, conf.NoWait)
+	if err != nil {
+		return
+	}
+
+	pub := newPublisher(c, exchangeName, channel)
+	pub.waitConfirmation = waitConfirmation
+	pub.queueName = queueName
+	pub.config = conf
+	c.health.addPublisher(pub)
+
+	p = pub
+	return
+}
+
+// publisherChannel creates a new channel for a publisher of an exchange or queue (targetKind), setting it into confirmation mode unless the NoWait flag is true
+func (c *client) publisherChannel(targetKind, targetName string, NoWait ...bool) (channel *amqp.Channel, waitConfirmation bool, err error) {
+	target := targetName + " " + targetKind
+
+	if c.conn == nil {
+		err = ErrConnectionNotOpen
+		return
+	}
+
+	waitConfirmation = true
 	if len(NoWait) > 0 {
 		waitConfirmation = !NoWait[0]
 	}
 
-	channel, err := c.conn.Channel()
+	channel, err = c.openChannel(targetKind, targetName, "publisher", true)
 	if err != nil {
-		err = fmt.Errorf("Failed to create a new channel for the %s exchange publisher, %v", exchangeName, err)
+		err = newError(err, "Failed to create a new channel for the %s publisher", target)
 		return
 	}
 
 	if waitConfirmation {
 		err = channel.Confirm(false)
 		if err != nil {
-			err = fmt.Errorf("Failed to set the %s exchange publisher into confirmation mode. Try setting the NoWait flag as true. %v", exchangeName, err)
+			err = newError(err, "Failed to set the %s publisher into confirmation mode. Try setting the NoWait flag as true", target)
 			return
 		}
 	}
 
-	p = newPublisher(exchangeName, channel)
+	if c.config.Metrics != nil || c.config.Logger != nil {
+		returns := channel.NotifyReturn(make(chan amqp.Return, 1))
+		go func() {
+			for r := range returns {
+				c.metrics().MessageReturned(r.Exchange)
+				c.logger().Warn(
+					"Message returned by the server",
+					"exchange", r.Exchange,
+					"routing_key", r.RoutingKey,
+					"message_id", r.MessageId,
+					"reply_code", r.ReplyCode,
+					"reply_text", r.ReplyText,
+				)
+			}
+		}()
+	}
+
 	return
 }
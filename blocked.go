@@ -0,0 +1,125 @@
+package amqp
+
+import (
+	"context"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Blocked returns if the server blocked the connection from publishing messages, i.e. due to a memory or disk alarm
+func (c *client) Blocked() bool {
+	blocked, _ := c.blockedState()
+	return blocked
+}
+
+// setBlocking updates the connection blocked state with a blocking notification sent by the server,
+// logging the change and queueing it to the OnBlockedChange function
+func (c *client) setBlocking(b amqp.Blocking) {
+	c.blockingMu.Lock()
+	changed := b.Active != c.blocking.Active
+	if changed && b.Active {
+		c.unblocked = make(chan struct{})
+	}
+	if changed && !b.Active {
+		close(c.unblocked)
+		c.unblocked = nil
+	}
+	c.blocking = b
+	c.blockingMu.Unlock()
+
+	if !changed {
+		return
+	}
+
+	if b.Active {
+		c.logger().Warn("AMQP connection blocked by the server", "reason", b.Reason)
+	} else {
+		c.logger().Info("AMQP connection unblocked by the server")
+	}
+
+	if c.config.OnBlockedChange != nil {
+		c.queueBlockedChange(b)
+	}
+}
+
+// queueBlockedChange queues a blocked state change to be notified to the OnBlockedChange function.
+//
+// The function is not called on the goroutine that receives the server notifications,
+// since it would stop the connection from receiving the unblock notification while the function runs (i.e. when it publishes a message).
+func (c *client) queueBlockedChange(b amqp.Blocking) {
+	c.blockedChangesMu.Lock()
+	c.blockedChanges = append(c.blockedChanges, b)
+	c.blockedChangesMu.Unlock()
+
+	select {
+	case c.blockedChangeSignal <- struct{}{}:
+	default:
+	}
+}
+
+// dispatchBlockedChanges calls the OnBlockedChange function for every queued blocked state change, in order,
+// until the connection is closed
+func (c *client) dispatchBlockedChanges() {
+	for range c.blockedChangeSignal {
+		for {
+			c.blockedChangesMu.Lock()
+			if len(c.blockedChanges) == 0 {
+				c.blockedChangesMu.Unlock()
+				break
+			}
+
+			b := c.blockedChanges[0]
+			c.blockedChanges = c.blockedChanges[1:]
+			c.blockedChangesMu.Unlock()
+
+			c.config.OnBlockedChange(b.Active, b.Reason)
+		}
+	}
+}
+
+// blockedState returns if the server blocked the connection, and the reason it was blocked
+func (c *client) blockedState() (blocked bool, reason string) {
+	c.blockingMu.RLock()
+	defer c.blockingMu.RUnlock()
+
+	return c.blocking.Active, c.blocking.Reason
+}
+
+// checkBlocked returns an error matching ErrConnectionBlocked if the server blocked the connection.
+//
+// When wait is true, it waits until the server unblocks the connection, or returns the error if the context is done first.
+func (c *client) checkBlocked(ctx context.Context, wait bool) error {
+	if c == nil {
+		return nil
+	}
+
+	c.blockingMu.RLock()
+	blocked, reason, unblocked := c.blocking.Active, c.blocking.Reason, c.unblocked
+	c.blockingMu.RUnlock()
+
+	if !blocked {
+		return nil
+	}
+
+	if !wait {
+		return newBlockedError(reason, nil)
+	}
+
+	select {
+	case <-unblocked:
+		return nil
+	case <-ctx.Done():
+		return newBlockedError(reason, ctx.Err())
+	}
+}
+
+// newBlockedError creates an Error matching ErrConnectionBlocked, wrapping the underlying error, if any
+func newBlockedError(reason string, err error) error {
+	return &Error{
+		Message:     fmt.Sprintf("The AMQP connection is blocked by the server (%s)", reason),
+		Recoverable: true,
+		Err:         err,
+		kind:        ErrConnectionBlocked,
+	}
+}
@@ -0,0 +1,19 @@
+package amqp
+
+import (
+	"fmt"
+)
+
+// CancelError represents the event of a consumer being cancelled by the server,
+// which happens when the queue is deleted or when the queue leader moves to another node
+type CancelError struct {
+	// Queue is the name of the queue that the consumer was subscribed to
+	Queue string
+	// Consumer is the name of the cancelled consumer
+	Consumer string
+}
+
+// Error returns the error message
+func (e *CancelError) Error() string {
+	return fmt.Sprintf("The %s consumer of the %s queue was cancelled by the server", e.Consumer, e.Queue)
+}
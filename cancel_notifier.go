@@ -0,0 +1,53 @@
+package amqp
+
+import (
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// cancelNotifier listens to the consumer cancellations sent by the server on a channel,
+// and dispatches them to the consumers watching them
+type cancelNotifier struct {
+	once     sync.Once
+	mu       sync.Mutex
+	watchers map[string]chan struct{}
+}
+
+// watch starts watching the consumer cancellation, returning a channel that is closed when the server cancels the consumer.
+// It starts to listen to the channel cancellations if it is the first consumer watched.
+//
+// It must be called before subscribing the consumer, so a cancellation sent right after the subscription is not missed.
+func (n *cancelNotifier) watch(ch *amqp.Channel, consumerName string) <-chan struct{} {
+	n.once.Do(func() {
+		n.watchers = map[string]chan struct{}{}
+		cancels := ch.NotifyCancel(make(chan string, 1))
+
+		go func() {
+			for consumerName := range cancels {
+				n.mu.Lock()
+				cancelled, ok := n.watchers[consumerName]
+				delete(n.watchers, consumerName)
+				n.mu.Unlock()
+
+				if ok {
+					close(cancelled)
+				}
+			}
+		}()
+	})
+
+	cancelled := make(chan struct{})
+	n.mu.Lock()
+	n.watchers[consumerName] = cancelled
+	n.mu.Unlock()
+
+	return cancelled
+}
+
+// unwatch stops watching the consumer cancellation
+func (n *cancelNotifier) unwatch(consumerName string) {
+	n.mu.Lock()
+	delete(n.watchers, consumerName)
+	n.mu.Unlock()
+}
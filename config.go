@@ -1,7 +1,9 @@
 package amqp
 
 import (
+	"context"
 	"crypto/tls"
+	"log/slog"
 	"net"
 	"time"
 
@@ -52,6 +54,50 @@ type Config struct {
 	// If Dial is nil, net.DialTimeout with a 30s connection and 30s deadline is
 	// used during TLS and AMQP handshaking.
 	Dial func(network, addr string) (net.Conn, error)
+
+	// OnPanic defines the function that is called when the message handling panics,
+	// including the handler function, the pre and post handle functions and the middlewares.
+	// The panic is recovered, and the consumer keeps consuming the queue.
+	//
+	// The function receives the recovered panic, with its stack trace, and returns the response used to acknowledge the message.
+	// When OnPanic is nil, the message is rejected, and handled with the *PanicError as the response error.
+	OnPanic func(ctx context.Context, d Delivery, err *PanicError) HandleResponse
+
+	// ErrorHandler handles the errors returned in the handle responses of every queue consumed through the client,
+	// along with the message, its delivery attempt and how it was acknowledged.
+	// It is called after the handlers of the queue itself.
+	//
+	// default: nil
+	ErrorHandler ErrorHandler
+
+	// Tracing enables the OpenTelemetry tracing of the message publishing and handling.
+	// The trace context is injected into the published message headers, and extracted from the consumed message headers
+	// into the handler context.
+	//
+	// default: nil (no tracing)
+	Tracing *TracingConfig
+
+	// Metrics collects the client metrics, such as the published and consumed messages, and the connection state.
+	// Use the NewMetrics function of the go-amqp/prometheus package to expose them as Prometheus metrics.
+	//
+	// default: nil (no metrics)
+	Metrics Metrics
+
+	// Logger logs the connection lifecycle, the channel closures, the acknowledgement failures,
+	// the redeliveries and the rejected and returned messages,
+	// with the exchange, queue, consumer and delivery tag as attributes, when they apply.
+	//
+	// default: nil (no logs)
+	Logger *slog.Logger
+
+	// OnBlockedChange defines the function that is called when the server blocks the connection from publishing messages,
+	// i.e. due to a memory or disk alarm, and when it unblocks the connection.
+	// It receives if the connection is blocked, and the reason sent by the server.
+	// The function is called on its own goroutine, in the order of the changes, so it can block or publish messages,
+	// but a slow function delays the notification of the following changes.
+	//
+	// default: nil
+	OnBlockedChange func(blocked bool, reason string)
 }
 
 func (c Config) toAMQPConfig() amqp.Config {
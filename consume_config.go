@@ -1,5 +1,9 @@
 package amqp
 
+import (
+	"time"
+)
+
 // ConsumeConfig represents the configuration that can be provided when consuming a queue
 type ConsumeConfig struct {
 	// When AutoAck is set to true, it means that as soon as a message is delivered to the consumer,
@@ -35,6 +39,76 @@ type ConsumeConfig struct {
 	// When a consumer name is not provided, the library will generate one based on the queue information.
 	ConsumerName string
 
+	// Middlewares are the middlewares that wrap the message handling of this consumer only.
+	// They run after the client, exchange and queue middlewares, in the order they were provided.
+	//
+	// default: nil
+	Middlewares []Middleware
+
+	// Priority defines the consumer priority.
+	// The server delivers the messages to the consumers with the highest priority while they are able to receive them,
+	// and only delivers messages to the lower priority consumers when the higher priority ones are busy or blocked.
+	// On a queue with a single active consumer, the highest priority consumer becomes the active consumer.
+	//
+	// default: 0
+	Priority int
+
+	// OnActiveChange defines a function that is called when the consumer becomes active or inactive.
+	//
+	// The AMQP protocol does not notify a consumer when it becomes active, so the library considers the consumer
+	// active when it receives its first message, and calls the function with true before that message is handled.
+	// When the consumer stops receiving messages, because it was cancelled or its channel was closed,
+	// the function is called with false.
+	//
+	// It is useful on queues with a single active consumer (and for leader-style workers in general),
+	// so the consumer can initialise its resources lazily, only when it is actually going to handle messages.
+	//
+	// default: nil
+	OnActiveChange func(active bool)
+
+	// OnCancel defines a function that is called when the server cancels the consumer,
+	// which happens when the queue is deleted or when the queue leader moves to another node.
+	// The function receives a *CancelError describing the cancelled consumer.
+	//
+	// When Resubscribe is set to true, the function is also called with the error of each failed resubscription attempt.
+	//
+	// default: nil
+	OnCancel func(err error)
+
+	// When Resubscribe is set to true, the library declares the queue again (binding it to its exchange)
+	// and subscribes a new consumer with the same configuration after the server cancels the consumer.
+	// Failed attempts are retried on every ResubscribeInterval, until the resubscription succeeds or the queue channel is closed.
+	//
+	// default: false
+	Resubscribe bool
+
+	// ResubscribeInterval defines the interval between the resubscription attempts.
+	//
+	// default: 1 second
+	ResubscribeInterval time.Duration
+
+	// PrefetchCount defines how many messages the server delivers to the consumer before receiving their acknowledgments.
+	// It is applied to the queue channel, so it affects the consumers started on the same channel after it.
+	// Streams require a prefetch count, so when consuming a stream without a prefetch count, the library uses 100.
+	//
+	// default: 0 (no limit)
+	PrefetchCount int
+
+	// StreamOffset defines where the consumer starts reading a stream.
+	// Setting a stream offset also makes the library consume the queue as a stream, even if it was not declared by the library.
+	//
+	// When the consumer has an offset stored in its OffsetStore, it resumes reading the stream after the stored offset instead.
+	//
+	// default: not set (the server starts reading from the next message)
+	StreamOffset StreamOffset
+
+	// OffsetStore defines where the last offset processed by a stream consumer is stored,
+	// so the consumer can resume reading the stream from where it stopped.
+	// The offsets are only tracked for stream consumers with a ConsumerName, since the name identifies the consumer between restarts.
+	//
+	// default: nil (the DefaultOffsetStore is used)
+	OffsetStore OffsetStore
+
 	// When declaring an consumer in AMQP, you can include a set of optional arguments to customize its behavior
 	// These arguments are provided as a collection of key-value pairs, where the keys represent specific configuration options,
 	// and the values determine the settings for those options.
@@ -2,40 +2,67 @@ package amqp
 
 import (
 	"context"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 // consumeLoop its the function that will be called whenever a message is consumed.
-// - calls the exchange and queue middlewares
-// - calls the handlerFunc to consume the message
+// - notifies when the consumer becomes active or inactive
+// - calls the handlerFunc, wrapped by the client, exchange, queue and consumer middlewares, to consume the message
+// - recovers from panics during the message handling
+// - traces the message handling, extracting the trace context from the message headers into the handler context
+// - reports the handling and acknowledgement metrics
+// - logs the redeliveries, the rejected messages and the acknowledgement failures
 // - treats the messaging response
-func consumeLoop(deliveries <-chan amqp.Delivery, q Queue, handlerFn HandlerFunc) {
+// - reports the handling errors to the queue and client error handlers
+// - stores the stream offset of the processed message, if the consumer tracks its offsets
+func consumeLoop(deliveries <-chan amqp.Delivery, c *consumer) {
+	defer c.setActive(false)
+
+	metrics := c.queue.exchange.client.metrics()
+	logger := c.logger()
+
 	for d := range deliveries {
-		msg := Delivery(d)
-		ctx := context.TODO()
+		c.setActive(true)
+		c.lastDelivery.Store(time.Now().UnixNano())
 
-		for _, preFunc := range q.Exchange().PreHandleFuncs() {
-			preFunc(&ctx, &msg)
-		}
-		for _, preFunc := range q.PreHandleFuncs() {
-			preFunc(&ctx, &msg)
+		if d.Redelivered {
+			logger.Info("Redelivered message received", "delivery_tag", d.DeliveryTag, "attempt", deliveryAttempt(Delivery(d)))
 		}
 
-		res := handlerFn(ctx, msg)
+		ctx, span := c.tracer().startConsume(context.TODO(), c.queue.name, Delivery(d))
+		metrics.HandlerStarted(c.queue.name)
+		start := time.Now()
+		res := c.handle(ctx, Delivery(d))
+		metrics.HandlerFinished(c.queue.name, time.Since(start))
 
-		for _, postFunc := range q.Exchange().PostHandleFuncs() {
-			postFunc(ctx, msg, res)
+		var ackErr error
+		switch res.outcome() {
+		case DeliveryOutcomeReject:
+			ackErr = d.Reject(false)
+		case DeliveryOutcomeNack:
+			ackErr = d.Nack(false, true)
+		default:
+			ackErr = d.Ack(false)
+			if ackErr == nil {
+				if offsetErr := c.saveOffset(Delivery(d)); offsetErr != nil {
+					logger.Error("Failed to save the stream offset", "delivery_tag", d.DeliveryTag, "error", offsetErr)
+				}
+			}
 		}
-		for _, postFunc := range q.PostHandleFuncs() {
-			postFunc(ctx, msg, res)
+
+		if ackErr != nil {
+			logger.Error("Failed to acknowledge message", "delivery_tag", d.DeliveryTag, "outcome", res.outcome().ToString(), "error", ackErr)
+		} else {
+			metrics.DeliveryConsumed(c.queue.name, res.outcome())
 		}
 
-		if res.Nack {
-			_ = d.Nack(false, true)
-			continue
+		if ackErr == nil && res.outcome() == DeliveryOutcomeReject {
+			logger.Warn("Message rejected, it was dropped or dead-lettered", "delivery_tag", d.DeliveryTag, "error", res.Err)
 		}
 
-		_ = d.Ack(false)
+		c.tracer().endConsume(span, res, ackErr)
+		c.reportError(ctx, Delivery(d), res)
 	}
 }
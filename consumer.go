@@ -0,0 +1,298 @@
+package amqp
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// consumerPriorityKey is the consume argument used to define the consumer priority
+const consumerPriorityKey = "x-priority"
+
+// defaultResubscribeInterval is the interval between the resubscription attempts when a consumer does not define one
+const defaultResubscribeInterval = time.Second
+
+// defaultStreamPrefetchCount is the prefetch count used when consuming a stream without a prefetch count
+const defaultStreamPrefetchCount = 100
+
+// consumer represents a subscription to a queue, that handles the queue messages with a handler function
+type consumer struct {
+	// name its the consumer name (tag) on the server
+	name string
+
+	// queue its the queue that the consumer is subscribed to
+	queue *amqpQueueBind
+
+	// handlerFn its the function that handles the messages
+	handlerFn HandlerFunc
+
+	// config its the configuration used to consume the queue
+	config ConsumeConfig
+
+	// offsets its where the stream offsets processed by the consumer are stored,
+	// or nil if the consumer does not track its offsets
+	offsets OffsetStore
+
+	// active defines if the consumer is active, meaning it has received messages since it was subscribed
+	active bool
+
+	// cancelled its closed when the server cancels the consumer
+	cancelled <-chan struct{}
+
+	// closed its closed when the consumer channel is closed
+	closed <-chan *amqp.Error
+
+	// running defines if the consumer is still receiving the queue messages
+	running atomic.Bool
+
+	// lastDelivery is when the consumer received its last message, in unix nanoseconds, or 0 if it did not receive any message
+	lastDelivery atomic.Int64
+}
+
+// run handles the consumer deliveries until they stop,
+// and then handles the consumer cancellation if the server cancelled the consumer
+func (c *consumer) run(deliveries <-chan amqp.Delivery) {
+	consumeLoop(deliveries, c)
+	c.running.Store(false)
+
+	// the deliveries stop either when the server cancels the consumer or when the channel is closed,
+	// and the cancellation may be dispatched after the deliveries stop, so it waits for one of them
+	cancelled := false
+	select {
+	case <-c.cancelled:
+		cancelled = true
+	case <-c.closed:
+	}
+
+	c.queue.exchange.cancels.unwatch(c.name)
+	if !cancelled {
+		return
+	}
+
+	c.logger().Warn("Consumer cancelled by the server")
+	c.notifyCancel(&CancelError{
+		Queue:    c.queue.name,
+		Consumer: c.name,
+	})
+
+	if c.config.Resubscribe {
+		c.resubscribe()
+	}
+}
+
+// resubscribe declares the queue again and subscribes a new consumer with the same configuration,
+// retrying on every resubscribe interval until it succeeds or the queue channel is closed
+func (c *consumer) resubscribe() {
+	interval := c.config.ResubscribeInterval
+	if interval <= 0 {
+		interval = defaultResubscribeInterval
+	}
+
+	for !c.queue.channel.IsClosed() {
+		err := c.queue.redeclare()
+		if err == nil {
+			err = c.queue.Consume(c.handlerFn, c.config)
+		}
+		if err == nil {
+			if cl := c.queue.exchange.client; cl != nil {
+				cl.health.removeConsumer(c)
+			}
+			return
+		}
+
+		c.logger().Error("Failed to resubscribe the consumer", "error", err)
+		c.notifyCancel(newError(err, "Failed to resubscribe the %s consumer", c.name))
+		time.Sleep(interval)
+	}
+}
+
+// notifyCancel calls the OnCancel function, if any
+func (c *consumer) notifyCancel(err error) {
+	if c.config.OnCancel != nil {
+		c.config.OnCancel(err)
+	}
+}
+
+// handle handles the message with the consumer handler function, recovering from panics during the handling
+func (c *consumer) handle(ctx context.Context, d Delivery) (res HandleResponse) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+
+		err := &PanicError{
+			Value: recovered,
+			Stack: debug.Stack(),
+		}
+
+		c.logger().Error("Recovered from a panic while handling message", "delivery_tag", d.DeliveryTag, "panic", recovered)
+
+		res = HandleResponse{Reject: true, Err: err}
+		if cl := c.queue.exchange.client; cl != nil && cl.config.OnPanic != nil {
+			res = cl.config.OnPanic(ctx, d, err)
+		}
+	}()
+
+	return c.handler()(ctx, d)
+}
+
+// reportError calls the queue and client error handlers, if the handle response has an error
+func (c *consumer) reportError(ctx context.Context, d Delivery, res HandleResponse) {
+	if res.Err == nil {
+		return
+	}
+
+	handlers := append([]ErrorHandler{}, c.queue.errorHandlers...)
+	if cl := c.queue.exchange.client; cl != nil && cl.config.ErrorHandler != nil {
+		handlers = append(handlers, cl.config.ErrorHandler)
+	}
+
+	deliveryErr := DeliveryError{
+		Queue:    c.queue.name,
+		Delivery: d,
+		Err:      res.Err,
+		Attempt:  deliveryAttempt(d),
+		Outcome:  res.outcome(),
+	}
+	for _, h := range handlers {
+		h.HandleError(ctx, deliveryErr)
+	}
+}
+
+// handler returns the consumer handler function wrapped by the middlewares, in the following order:
+// the client, exchange, queue and consumer middlewares, and then the exchange and queue pre and post handle functions.
+//
+// The handler is built for each message, so the middlewares and handle functions added after the consumer started are also used.
+func (c *consumer) handler() HandlerFunc {
+	q := c.queue
+	e := q.exchange
+
+	middlewares := []Middleware{}
+	if e.client != nil {
+		middlewares = append(middlewares, e.client.middlewares...)
+	}
+	middlewares = append(middlewares, e.middlewares...)
+	middlewares = append(middlewares, q.middlewares...)
+	middlewares = append(middlewares, c.config.Middlewares...)
+
+	preFuncs := append([]PreHandleFunc{}, e.preHandleFuncs...)
+	preFuncs = append(preFuncs, q.preHandleFuncs...)
+	postFuncs := append([]PostHandleFunc{}, e.postHandleFuncs...)
+	postFuncs = append(postFuncs, q.postHandleFuncs...)
+	middlewares = append(middlewares, PreHandleMiddleware(preFuncs...), PostHandleMiddleware(postFuncs...))
+
+	return chainMiddlewares(c.handlerFn, middlewares...)
+}
+
+// consumeArgs returns the consume arguments, including the consumer priority, if any
+func (c *consumer) consumeArgs(args Table) (Table, error) {
+	if c.config.Priority == 0 {
+		return args, nil
+	}
+
+	if _, ok := args[consumerPriorityKey]; ok {
+		return nil, newConfigError(nil, "The %s argument is defined both in the Args and in the Priority field", consumerPriorityKey)
+	}
+
+	withPriority := Table{consumerPriorityKey: int64(c.config.Priority)}
+	for k, v := range args {
+		withPriority[k] = v
+	}
+
+	return withPriority, nil
+}
+
+// logger returns the client logger, with the attributes identifying the consumer
+func (c *consumer) logger() *slog.Logger {
+	return c.queue.exchange.client.logger().With(
+		"exchange", c.queue.exchange.name,
+		"queue", c.queue.name,
+		"consumer", c.name,
+	)
+}
+
+// tracer returns the tracer of the client, or nil if the client does not trace the message handling
+func (c *consumer) tracer() *tracer {
+	if cl := c.queue.exchange.client; cl != nil {
+		return cl.tracer
+	}
+
+	return nil
+}
+
+// setActive updates if the consumer is active, calling the OnActiveChange function when it changes
+func (c *consumer) setActive(active bool) {
+	if c.active == active {
+		return
+	}
+
+	c.active = active
+	if c.config.OnActiveChange != nil {
+		c.config.OnActiveChange(active)
+	}
+}
+
+// isStream returns if the consumer must consume the queue as a stream
+func (c *consumer) isStream() bool {
+	return c.queue.queueType == QueueTypeStream || c.config.StreamOffset.isSet()
+}
+
+// prepareStream validates the consumer configuration for a stream, loads the consumer stored offset if it tracks its offsets,
+// and returns the consume arguments with the stream offset
+func (c *consumer) prepareStream() (args Table, err error) {
+	if c.config.AutoAck {
+		err = newConfigError(nil, "Streams can not be consumed with AutoAck")
+		return
+	}
+
+	if c.config.PrefetchCount == 0 {
+		c.config.PrefetchCount = defaultStreamPrefetchCount
+	}
+
+	offset := c.config.StreamOffset
+	if c.config.ConsumerName != "" {
+		c.offsets = c.config.OffsetStore
+		if c.offsets == nil {
+			c.offsets = DefaultOffsetStore
+		}
+
+		stored, found, loadErr := c.offsets.Load(c.name)
+		if loadErr != nil {
+			err = newError(loadErr, "Failed to load the stream offset")
+			return
+		}
+
+		if found {
+			offset = StreamOffsetAt(stored + 1)
+		}
+	}
+
+	args = Table{}
+	for k, v := range c.config.Args {
+		args[k] = v
+	}
+	if offset.isSet() {
+		args[streamOffsetKey] = offset.value
+	}
+
+	return
+}
+
+// saveOffset stores the stream offset of a processed message, if the consumer tracks its offsets
+func (c *consumer) saveOffset(d Delivery) error {
+	if c.offsets == nil {
+		return nil
+	}
+
+	offset, ok := d.Headers[streamOffsetKey].(int64)
+	if !ok {
+		return nil
+	}
+
+	return c.offsets.Save(c.name, offset)
+}
@@ -0,0 +1,43 @@
+package amqp
+
+// DeadLetterConfig represents the dead-letter topology that is declared along with a queue.
+//
+// When a queue has a dead-letter topology, the library declares a direct dead-letter exchange
+// and a dead-letter queue bound to it, using the main queue name as the routing-key.
+// The messages rejected, nacked without requeue, expired or dropped from the main queue are then routed to the dead-letter queue.
+type DeadLetterConfig struct {
+	// ExchangeName is the name of the dead-letter exchange.
+	// The same dead-letter exchange can be shared by many queues,
+	// since every dead-letter queue is bound to it using its main queue name as the routing-key.
+	//
+	// default: "<queue name>.dlx"
+	ExchangeName string
+
+	// QueueName is the name of the dead-letter queue.
+	//
+	// default: "<queue name>.dlq"
+	QueueName string
+
+	// Queue is the configuration used to declare the dead-letter queue.
+	//
+	// default: a queue with the same durability as the main queue
+	Queue *QueueBindConfig
+}
+
+// exchangeName returns the dead-letter exchange name for the main queue
+func (c DeadLetterConfig) exchangeName(queueName string) string {
+	if c.ExchangeName != "" {
+		return c.ExchangeName
+	}
+
+	return queueName + ".dlx"
+}
+
+// queueName returns the dead-letter queue name for the main queue
+func (c DeadLetterConfig) queueName(queueName string) string {
+	if c.QueueName != "" {
+		return c.QueueName
+	}
+
+	return queueName + ".dlq"
+}
@@ -0,0 +1,92 @@
+package amqp
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultDedupTTL is for how long a processed message is remembered when the deduplication config does not define a TTL
+const defaultDedupTTL = 24 * time.Hour
+
+// DedupStore represents a storage for the keys of the messages that were already processed,
+// used to skip the duplicated messages
+type DedupStore interface {
+	// Seen returns if a message with the key was already processed
+	Seen(ctx context.Context, key string) (bool, error)
+
+	// MarkDone marks the message with the key as processed, remembering it for the ttl duration
+	MarkDone(ctx context.Context, key string, ttl time.Duration) error
+}
+
+// DedupConfig represents the configuration that can be provided to the deduplication middleware
+type DedupConfig struct {
+	// KeyFunc returns the key that identifies a message.
+	// The messages with an empty key are not deduplicated.
+	//
+	// default: the message MessageId
+	KeyFunc func(Delivery) string
+
+	// TTL defines for how long a processed message is remembered.
+	// It should be longer than the time a duplicated message can take to be redelivered.
+	//
+	// default: 24 hours
+	TTL time.Duration
+}
+
+// Deduplicate returns a middleware that skips the messages that were already processed, using the store to remember them.
+//
+// A message is only marked as processed when the handling succeeds, meaning the handle response has no error and the message is acknowledged,
+// so messages that failed can be processed again.
+// The skipped messages are acknowledged without calling the wrapped handler function.
+// When the store fails to check a message, the message is nacked so it can be processed later.
+func Deduplicate(store DedupStore, conf ...DedupConfig) Middleware {
+	config := DedupConfig{}
+	if len(conf) > 0 {
+		config = conf[0]
+	}
+
+	keyFunc := config.KeyFunc
+	if keyFunc == nil {
+		keyFunc = func(d Delivery) string {
+			return d.MessageId
+		}
+	}
+
+	ttl := config.TTL
+	if ttl <= 0 {
+		ttl = defaultDedupTTL
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, d Delivery) HandleResponse {
+			key := keyFunc(d)
+			if key == "" {
+				return next(ctx, d)
+			}
+
+			seen, err := store.Seen(ctx, key)
+			if err != nil {
+				return HandleResponse{
+					Nack: true,
+					Err:  fmt.Errorf("Failed to check if the %s message was already processed, %w", key, err),
+				}
+			}
+			if seen {
+				return HandleResponse{}
+			}
+
+			res := next(ctx, d)
+			if res.Err != nil || res.outcome() != DeliveryOutcomeAck {
+				return res
+			}
+
+			err = store.MarkDone(ctx, key, ttl)
+			if err != nil {
+				res.Err = fmt.Errorf("Failed to mark the %s message as processed, %w", key, err)
+			}
+
+			return res
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package amqp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fileDedupStore represents a deduplication store that keeps the processed keys in memory,
+// and persists them in an append-only file so they are kept between restarts
+type fileDedupStore struct {
+	path string
+
+	mu      sync.Mutex
+	file    *os.File
+	entries map[string]time.Time
+}
+
+// NewFileDedupStore returns a deduplication store that persists the processed keys in a file,
+// so they are kept between application restarts.
+//
+// The file is loaded, and compacted to remove the expired keys, when the store is created.
+// Since the file is local, the keys are not shared between application instances.
+func NewFileDedupStore(path string) (DedupStore, error) {
+	s := &fileDedupStore{
+		path:    path,
+		entries: map[string]time.Time{},
+	}
+
+	err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.compact()
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Seen returns if the key was processed and has not expired yet
+func (s *fileDedupStore) Seen(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.entries[key]
+	if !ok {
+		return false, nil
+	}
+
+	if time.Now().After(expiresAt) {
+		delete(s.entries, key)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// MarkDone marks the key as processed, appending it to the file
+func (s *fileDedupStore) MarkDone(_ context.Context, key string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	_, err := fmt.Fprintf(s.file, "%s %d\n", url.QueryEscape(key), expiresAt.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("Failed to write the %s key to the deduplication file, %w", key, err)
+	}
+
+	s.entries[key] = expiresAt
+	return nil
+}
+
+// load reads the keys that have not expired yet from the file, if it exists
+func (s *fileDedupStore) load() error {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to open the deduplication file, %w", err)
+	}
+	defer f.Close()
+
+	now := time.Now()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		key, keyErr := url.QueryUnescape(fields[0])
+		expiresAt, expiresErr := strconv.ParseInt(fields[1], 10, 64)
+		if keyErr != nil || expiresErr != nil {
+			continue
+		}
+
+		if t := time.UnixMilli(expiresAt); t.After(now) {
+			s.entries[key] = t
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return fmt.Errorf("Failed to read the deduplication file, %w", err)
+	}
+
+	return nil
+}
+
+// compact rewrites the file with the loaded keys only, and opens it to append the new keys
+func (s *fileDedupStore) compact() error {
+	tmp := s.path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("Failed to compact the deduplication file, %w", err)
+	}
+
+	w := bufio.NewWriter(f)
+	for key, expiresAt := range s.entries {
+		fmt.Fprintf(w, "%s %d\n", url.QueryEscape(key), expiresAt.UnixMilli())
+	}
+
+	err = w.Flush()
+	if err == nil {
+		err = f.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp, s.path)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to compact the deduplication file, %w", err)
+	}
+
+	s.file, err = os.OpenFile(s.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("Failed to open the deduplication file, %w", err)
+	}
+
+	return nil
+}
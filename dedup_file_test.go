@@ -0,0 +1,145 @@
+package amqp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileDedupStore(t *testing.T) {
+	type mark struct {
+		key string
+		ttl time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		marks   []mark
+		reopen  bool
+		want    map[string]bool
+		wantLen int
+	}{
+		{
+			name:  "keeps the marked keys",
+			marks: []mark{{"a", time.Hour}, {"b", time.Hour}},
+			want:  map[string]bool{"a": true, "b": true, "c": false},
+		},
+		{
+			name:  "expires the keys after the ttl",
+			marks: []mark{{"a", -time.Second}, {"b", time.Hour}},
+			want:  map[string]bool{"a": false, "b": true},
+		},
+		{
+			name:  "marking an expired key again renews it",
+			marks: []mark{{"a", -time.Second}, {"a", time.Hour}},
+			want:  map[string]bool{"a": true},
+		},
+		{
+			name:    "keeps the keys between restarts",
+			marks:   []mark{{"a", time.Hour}, {"order 1/created", time.Hour}},
+			reopen:  true,
+			want:    map[string]bool{"a": true, "order 1/created": true, "b": false},
+			wantLen: 2,
+		},
+		{
+			name:    "drops the expired keys when compacting on restart",
+			marks:   []mark{{"a", -time.Second}, {"b", time.Hour}, {"c", -time.Second}},
+			reopen:  true,
+			want:    map[string]bool{"a": false, "b": true, "c": false},
+			wantLen: 1,
+		},
+		{
+			name:    "keeps only the last mark of a key on restart",
+			marks:   []mark{{"a", -time.Second}, {"a", time.Hour}, {"a", time.Hour}},
+			reopen:  true,
+			want:    map[string]bool{"a": true},
+			wantLen: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "dedup")
+
+			store, err := NewFileDedupStore(path)
+			if err != nil {
+				t.Fatalf("NewFileDedupStore() returned an unexpected error: %v", err)
+			}
+
+			for _, m := range tt.marks {
+				err = store.MarkDone(ctx, m.key, m.ttl)
+				if err != nil {
+					t.Fatalf("MarkDone(%q) returned an unexpected error: %v", m.key, err)
+				}
+			}
+
+			if tt.reopen {
+				store.(*fileDedupStore).file.Close()
+
+				store, err = NewFileDedupStore(path)
+				if err != nil {
+					t.Fatalf("NewFileDedupStore() returned an unexpected error when reopening: %v", err)
+				}
+
+				content, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatalf("Failed to read the deduplication file: %v", err)
+				}
+
+				lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+				if len(lines) != tt.wantLen {
+					t.Errorf("compacted file has %d lines, want %d", len(lines), tt.wantLen)
+				}
+			}
+			defer store.(*fileDedupStore).file.Close()
+
+			for key, want := range tt.want {
+				got, err := store.Seen(ctx, key)
+				if err != nil {
+					t.Fatalf("Seen(%q) returned an unexpected error: %v", key, err)
+				}
+
+				if got != want {
+					t.Errorf("Seen(%q) = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFileDedupStoreIgnoresMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dedup")
+	expiresAt := time.Now().Add(time.Hour).UnixMilli()
+	content := strings.Join([]string{
+		"valid " + strconv.FormatInt(expiresAt, 10),
+		"missing-expiration",
+		"bad-expiration soon",
+		"%zz " + strconv.FormatInt(expiresAt, 10),
+		"",
+	}, "\n")
+
+	err := os.WriteFile(path, []byte(content), 0o644)
+	if err != nil {
+		t.Fatalf("Failed to write the deduplication file: %v", err)
+	}
+
+	store, err := NewFileDedupStore(path)
+	if err != nil {
+		t.Fatalf("NewFileDedupStore() returned an unexpected error: %v", err)
+	}
+	defer store.(*fileDedupStore).file.Close()
+
+	entries := store.(*fileDedupStore).entries
+	if len(entries) != 1 {
+		t.Fatalf("loaded %d keys, want 1: %v", len(entries), entries)
+	}
+
+	if _, ok := entries["valid"]; !ok {
+		t.Errorf("the valid key was not loaded: %v", entries)
+	}
+}
@@ -0,0 +1,82 @@
+package amqp
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryDedupStore represents a deduplication store that keeps the processed keys in memory,
+// evicting the least recently used keys when its capacity is reached
+type memoryDedupStore struct {
+	capacity int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+// memoryDedupEntry represents a processed key kept in memory
+type memoryDedupEntry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// NewMemoryDedupStore returns a deduplication store that keeps up to capacity processed keys in memory.
+//
+// The keys expire after their TTL, and the least recently used keys are evicted when the capacity is reached.
+// Since the keys are kept in memory, they are not shared between application instances, nor kept between restarts.
+func NewMemoryDedupStore(capacity int) DedupStore {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &memoryDedupStore{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		lru:      list.New(),
+	}
+}
+
+// Seen returns if the key was processed and has not expired yet
+func (s *memoryDedupStore) Seen(_ context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.entries[key]
+	if !ok {
+		return false, nil
+	}
+
+	if time.Now().After(el.Value.(*memoryDedupEntry).expiresAt) {
+		s.lru.Remove(el)
+		delete(s.entries, key)
+		return false, nil
+	}
+
+	s.lru.MoveToFront(el)
+	return true, nil
+}
+
+// MarkDone marks the key as processed, evicting the least recently used key if the capacity is reached
+func (s *memoryDedupStore) MarkDone(_ context.Context, key string, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if el, ok := s.entries[key]; ok {
+		el.Value.(*memoryDedupEntry).expiresAt = expiresAt
+		s.lru.MoveToFront(el)
+		return nil
+	}
+
+	s.entries[key] = s.lru.PushFront(&memoryDedupEntry{key, expiresAt})
+	for s.lru.Len() > s.capacity {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryDedupEntry).key)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package amqp
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryDedupStore(t *testing.T) {
+	type mark struct {
+		key string
+		ttl time.Duration
+	}
+
+	tests := []struct {
+		name     string
+		capacity int
+		marks    []mark
+		seenKeys []string
+		after    []mark
+		want     map[string]bool
+	}{
+		{
+			name:     "keeps the keys under the capacity",
+			capacity: 2,
+			marks:    []mark{{"a", time.Hour}, {"b", time.Hour}},
+			want:     map[string]bool{"a": true, "b": true, "c": false},
+		},
+		{
+			name:     "evicts the least recently marked key",
+			capacity: 2,
+			marks:    []mark{{"a", time.Hour}, {"b", time.Hour}, {"c", time.Hour}},
+			want:     map[string]bool{"a": false, "b": true, "c": true},
+		},
+		{
+			name:     "a seen key is not the least recently used",
+			capacity: 2,
+			marks:    []mark{{"a", time.Hour}, {"b", time.Hour}},
+			seenKeys: []string{"a"},
+			after:    []mark{{"c", time.Hour}},
+			want:     map[string]bool{"a": true, "b": false, "c": true},
+		},
+		{
+			name:     "marking a key again does not evict another key",
+			capacity: 2,
+			marks:    []mark{{"a", time.Hour}, {"b", time.Hour}, {"a", time.Hour}},
+			want:     map[string]bool{"a": true, "b": true},
+		},
+		{
+			name:     "expires the keys after the ttl, even under the capacity",
+			capacity: 10,
+			marks:    []mark{{"a", -time.Second}, {"b", time.Hour}},
+			want:     map[string]bool{"a": false, "b": true},
+		},
+		{
+			name:     "marking an expired key again renews it",
+			capacity: 10,
+			marks:    []mark{{"a", -time.Second}, {"a", time.Hour}},
+			want:     map[string]bool{"a": true},
+		},
+		{
+			name:     "an expired key is evicted as the least recently used",
+			capacity: 2,
+			marks:    []mark{{"a", -time.Second}, {"b", time.Hour}, {"c", time.Hour}},
+			want:     map[string]bool{"a": false, "b": true, "c": true},
+		},
+		{
+			name:     "a capacity below one keeps one key",
+			capacity: 0,
+			marks:    []mark{{"a", time.Hour}, {"b", time.Hour}},
+			want:     map[string]bool{"a": false, "b": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := NewMemoryDedupStore(tt.capacity)
+
+			markDone := func(marks []mark) {
+				for _, m := range marks {
+					err := store.MarkDone(ctx, m.key, m.ttl)
+					if err != nil {
+						t.Fatalf("MarkDone(%q) returned an unexpected error: %v", m.key, err)
+					}
+				}
+			}
+
+			markDone(tt.marks)
+			for _, key := range tt.seenKeys {
+				store.Seen(ctx, key)
+			}
+			markDone(tt.after)
+
+			for key, want := range tt.want {
+				got, err := store.Seen(ctx, key)
+				if err != nil {
+					t.Fatalf("Seen(%q) returned an unexpected error: %v", key, err)
+				}
+
+				if got != want {
+					t.Errorf("Seen(%q) = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package amqp
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// sqlIdentifier matches the table names accepted by the SQL deduplication store
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// SQLPlaceholder represents the bind parameter style used by a SQL driver
+type SQLPlaceholder string
+
+const (
+	// SQLPlaceholderQuestion uses '?' as the bind parameter (i.e. MySQL, SQLite)
+	SQLPlaceholderQuestion = SQLPlaceholder("?")
+	// SQLPlaceholderDollar uses '$1', '$2', ... as the bind parameters (i.e. PostgreSQL)
+	SQLPlaceholderDollar = SQLPlaceholder("$")
+)
+
+// SQLDedupConfig represents the configuration of the SQL deduplication store
+type SQLDedupConfig struct {
+	// Table is the name of the table where the processed keys are stored.
+	// The table must have a text "dedup_key" primary key column, and a big integer "expires_at" column,
+	// where the key expiration is stored as unix milliseconds:
+	//
+	//	CREATE TABLE amqp_dedup (dedup_key VARCHAR(255) PRIMARY KEY, expires_at BIGINT NOT NULL)
+	//
+	// default: "amqp_dedup"
+	Table string
+
+	// Placeholder is the bind parameter style used by the database driver.
+	//
+	// default: SQLPlaceholderQuestion
+	Placeholder SQLPlaceholder
+}
+
+// sqlDedupStore represents a deduplication store that keeps the processed keys in a SQL database table,
+// so they are shared between application instances
+type sqlDedupStore struct {
+	db *sql.DB
+
+	seenQuery,
+	deleteQuery,
+	insertQuery string
+}
+
+// NewSQLDedupStore returns a deduplication store that keeps the processed keys in a SQL database table,
+// so they are shared between application instances and kept between restarts.
+//
+// The expired keys are not deleted from the table automatically, so they should be cleaned up periodically.
+func NewSQLDedupStore(db *sql.DB, conf ...SQLDedupConfig) (DedupStore, error) {
+	config := SQLDedupConfig{}
+	if len(conf) > 0 {
+		config = conf[0]
+	}
+
+	table := config.Table
+	if table == "" {
+		table = "amqp_dedup"
+	}
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("Invalid deduplication table name %q", table)
+	}
+
+	param := func(i int) string {
+		if config.Placeholder == SQLPlaceholderDollar {
+			return fmt.Sprintf("$%d", i)
+		}
+		return "?"
+	}
+
+	return &sqlDedupStore{
+		db:          db,
+		seenQuery:   fmt.Sprintf("SELECT 1 FROM %s WHERE dedup_key = %s AND expires_at > %s", table, param(1), param(2)),
+		deleteQuery: fmt.Sprintf("DELETE FROM %s WHERE dedup_key = %s", table, param(1)),
+		insertQuery: fmt.Sprintf("INSERT INTO %s (dedup_key, expires_at) VALUES (%s, %s)", table, param(1), param(2)),
+	}, nil
+}
+
+// Seen returns if the key was processed and has not expired yet
+func (s *sqlDedupStore) Seen(ctx context.Context, key string) (bool, error) {
+	var found int
+	err := s.db.QueryRowContext(ctx, s.seenQuery, key, time.Now().UnixMilli()).Scan(&found)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("Failed to query the deduplication table, %w", err)
+	}
+
+	return true, nil
+}
+
+// MarkDone marks the key as processed, replacing its previous expiration, if any
+func (s *sqlDedupStore) MarkDone(ctx context.Context, key string, ttl time.Duration) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("Failed to start the deduplication transaction, %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, s.deleteQuery, key)
+	if err != nil {
+		return fmt.Errorf("Failed to delete the %s key from the deduplication table, %w", key, err)
+	}
+
+	_, err = tx.ExecContext(ctx, s.insertQuery, key, time.Now().Add(ttl).UnixMilli())
+	if err != nil {
+		return fmt.Errorf("Failed to insert the %s key in the deduplication table, %w", key, err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("Failed to commit the deduplication transaction, %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package amqp
+
+// DelayStrategy represents how a publisher delays the messages published with a Delay
+type DelayStrategy string
+
+const (
+	// DelayStrategyPlugin delays the messages using the delayed message exchange plugin.
+	// The message is published with the "x-delay" header, and the exchange holds it until the delay is over.
+	//
+	// The publisher exchange must be declared with the ExchangeTypeDelayed type, otherwise the message is delivered immediately.
+	DelayStrategyPlugin = DelayStrategy("plugin")
+
+	// DelayStrategyQueues delays the messages using library-managed delay queues, for servers without the delayed message plugin.
+	//
+	// For each exchange (or queue, for queue publishers), the library declares a fixed delay ladder:
+	// a set of levels where the level n holds the messages for 2^n times the publisher DelayPrecision,
+	// using a queue with a message TTL that dead-letters the expired messages to the level below.
+	// The delay is rounded to the nearest multiple of the precision, and the message waits only on the levels of its binary representation,
+	// being routed to the publisher exchange with its original routing-key when the delay is over.
+	DelayStrategyQueues = DelayStrategy("queues")
+)
+
+// ToString returns the string notation of the delay strategy
+func (s DelayStrategy) ToString() string {
+	return string(s)
+}
@@ -0,0 +1,81 @@
+package amqp
+
+import (
+	"context"
+)
+
+// deliveryCountHeader is the header where quorum queues keep how many times a message was delivered before
+const deliveryCountHeader = "x-delivery-count"
+
+// DeliveryOutcome represents how a message was acknowledged after its handling
+type DeliveryOutcome string
+
+const (
+	// DeliveryOutcomeAck means the message was acknowledged, and removed from the queue
+	DeliveryOutcomeAck = DeliveryOutcome("ack")
+	// DeliveryOutcomeNack means the message was not acknowledged, and was requeued
+	DeliveryOutcomeNack = DeliveryOutcome("nack")
+	// DeliveryOutcomeReject means the message was rejected without being requeued, so it was dropped or dead-lettered
+	DeliveryOutcomeReject = DeliveryOutcome("reject")
+)
+
+// ToString returns the string notation of the delivery outcome
+func (o DeliveryOutcome) ToString() string {
+	return string(o)
+}
+
+// DeliveryError represents an error that occurred while handling a message
+type DeliveryError struct {
+	// Queue is the name of the queue where the message was received
+	Queue string
+	// Delivery is the message that was being handled
+	Delivery Delivery
+	// Err is the error returned in the handle response, or a *PanicError if the handling panicked
+	Err error
+	// Attempt is the number of the delivery attempt of the message, starting at 1.
+	// It is exact on quorum queues, which count the message deliveries, and is at least 2 for other redelivered messages.
+	Attempt int
+	// Outcome is how the message was acknowledged after the error
+	Outcome DeliveryOutcome
+}
+
+// ErrorHandler represents a handler for the errors that occur while handling the messages,
+// so they can be logged, counted or sent to an error tracker uniformly
+type ErrorHandler interface {
+	// HandleError handles an error that occurred while handling a message
+	HandleError(ctx context.Context, err DeliveryError)
+}
+
+// ErrorHandlerFunc is a function adapter for the ErrorHandler interface
+type ErrorHandlerFunc func(ctx context.Context, err DeliveryError)
+
+// HandleError calls the function itself
+func (f ErrorHandlerFunc) HandleError(ctx context.Context, err DeliveryError) {
+	f(ctx, err)
+}
+
+// outcome returns how the message is acknowledged according to the handle response
+func (r HandleResponse) outcome() DeliveryOutcome {
+	switch {
+	case r.Reject:
+		return DeliveryOutcomeReject
+	case r.Nack:
+		return DeliveryOutcomeNack
+	default:
+		return DeliveryOutcomeAck
+	}
+}
+
+// deliveryAttempt returns the number of the delivery attempt of a message, starting at 1
+func deliveryAttempt(d Delivery) int {
+	count := normalizeNumber(d.Headers[deliveryCountHeader])
+	if count, ok := count.(int64); ok {
+		return int(count) + 1
+	}
+
+	if d.Redelivered {
+		return 2
+	}
+
+	return 1
+}
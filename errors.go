@@ -0,0 +1,132 @@
+package amqp
+
+import (
+	"errors"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	// ErrConnectionNotOpen is returned when the client connection was not opened
+	ErrConnectionNotOpen = errors.New("The AMQP connection is not open")
+	// ErrConnectionClosed is returned when the client connection was closed, by the client or by the server
+	ErrConnectionClosed = errors.New("AMQP disconnected")
+	// ErrChannelClosed is returned when an operation is done on a closed channel
+	ErrChannelClosed = errors.New("The AMQP channel is closed")
+	// ErrNotFound is returned when the server does not find an exchange or queue
+	ErrNotFound = errors.New("The AMQP resource was not found")
+	// ErrAccessRefused is returned when the server refuses the access to the connection, exchange or queue
+	ErrAccessRefused = errors.New("The access to the AMQP resource was refused")
+	// ErrResourceLocked is returned when the server refuses the access to a queue that is exclusive to another connection
+	ErrResourceLocked = errors.New("The AMQP resource is locked")
+	// ErrPreconditionFailed is returned when the server refuses an operation because its arguments do not match the resource,
+	// i.e. when an exchange or queue is declared again with different arguments
+	ErrPreconditionFailed = errors.New("The AMQP precondition failed")
+	// ErrConnectionBlocked is returned when a message is published while the server blocks the connection,
+	// i.e. due to a memory or disk alarm
+	ErrConnectionBlocked = errors.New("The AMQP connection is blocked by the server")
+	// ErrNotAcknowledged is returned when the server does not acknowledge a message publishing
+	ErrNotAcknowledged = errors.New("The server did not acknowledge the message publishing")
+	// ErrInvalidConfig is returned when an exchange, queue, consumer or publisher configuration is invalid
+	ErrInvalidConfig = errors.New("Invalid configuration")
+)
+
+// replyCodeErrors are the sentinel errors of the AMQP reply codes
+var replyCodeErrors = map[int]error{
+	amqp.ConnectionForced:   ErrConnectionClosed,
+	amqp.ChannelError:       ErrChannelClosed,
+	amqp.NotFound:           ErrNotFound,
+	amqp.AccessRefused:      ErrAccessRefused,
+	amqp.ResourceLocked:     ErrResourceLocked,
+	amqp.PreconditionFailed: ErrPreconditionFailed,
+}
+
+// ErrorClass represents the class of an AMQP error, according to what the server closes when the error occurs
+type ErrorClass string
+
+const (
+	// ErrorClassChannel means the error closed the channel, and the connection can still be used
+	ErrorClassChannel = ErrorClass("channel")
+	// ErrorClassConnection means the error closed the connection
+	ErrorClassConnection = ErrorClass("connection")
+)
+
+// ToString returns the string notation of the error class
+func (c ErrorClass) ToString() string {
+	return string(c)
+}
+
+// Error represents an error returned by the client, exchanges, queues and publishers.
+//
+// It wraps the underlying error, so it can be inspected with errors.Is and errors.As,
+// and matches the sentinel error of its AMQP reply code, i.e. errors.Is(err, ErrNotFound).
+type Error struct {
+	// Message describes the operation that failed
+	Message string
+	// Code is the AMQP reply code of the error, or 0 if the error did not come from the server
+	Code int
+	// Class is the class of the AMQP error, or empty if the error was not sent by the server
+	Class ErrorClass
+	// Recoverable defines if the operation can succeed when retried later or with different parameters,
+	// according to the server
+	Recoverable bool
+	// Err is the underlying error
+	Err error
+
+	// kind is the sentinel error matched by the error, if any
+	kind error
+}
+
+// Error returns the error message, followed by the underlying error message
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.Message
+	}
+
+	return e.Message + ", " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is returns if the error matches the target sentinel error
+func (e *Error) Is(target error) bool {
+	return e.kind != nil && e.kind == target
+}
+
+// newError creates an Error with the formatted message, wrapping the underlying error,
+// and exposing its AMQP reply code, class and if it is recoverable when it comes from the server
+func newError(err error, format string, args ...any) error {
+	e := &Error{
+		Message: fmt.Sprintf(format, args...),
+		Err:     err,
+	}
+
+	var amqpErr *amqp.Error
+	if errors.As(err, &amqpErr) {
+		e.Code = amqpErr.Code
+		e.Recoverable = amqpErr.Recover
+		e.kind = replyCodeErrors[amqpErr.Code]
+
+		if amqpErr.Server {
+			e.Class = ErrorClassConnection
+			if amqpErr.Recover {
+				e.Class = ErrorClassChannel
+			}
+		}
+	}
+
+	return e
+}
+
+// newConfigError creates an Error with the formatted message, that matches the ErrInvalidConfig sentinel error
+func newConfigError(err error, format string, args ...any) error {
+	return &Error{
+		Message: fmt.Sprintf(format, args...),
+		Err:     err,
+		kind:    ErrInvalidConfig,
+	}
+}
@@ -1,14 +1,16 @@
 package amqp
 
 import (
-	"fmt"
-
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type amqpExchange struct {
 	connectedStruct
 	name string
+	kind ExchangeType
+
+	// client its the client that started the exchange
+	client *client
 
 	channel *amqp.Channel
 
@@ -17,11 +19,25 @@ type amqpExchange struct {
 
 	// postHandleFuncs are the functions that will be called after the message handling
 	postHandleFuncs []PostHandleFunc
+
+	// middlewares are the middlewares that wrap the message handling
+	middlewares []Middleware
+
+	// bindings are the exchange-to-exchange bindings where this exchange is the destination
+	bindings []ExchangeBinding
+
+	// unroutable its the queue that captures the messages routed to the exchange alternate exchange, if any
+	unroutable Queue
+
+	// cancels dispatches the consumer cancellations sent by the server on the exchange channel
+	cancels cancelNotifier
 }
 
-func newExchange(exchangeName string, ch *amqp.Channel) *amqpExchange {
+func newExchange(c *client, exchangeName string, exchangeType ExchangeType, ch *amqp.Channel) *amqpExchange {
 	return &amqpExchange{
 		name:    exchangeName,
+		kind:    exchangeType,
+		client:  c,
 		channel: ch,
 		connectedStruct: connectedStruct{
 			ch: ch,
@@ -36,44 +52,264 @@ func (e *amqpExchange) BindQueue(queueName, routingKey string, conf ...QueueBind
 		config = conf[0]
 	}
 
-	_, err = e.channel.QueueDeclare(
+	queue, err := e.declareQueue(queueName, routingKey, config)
+	if err != nil {
+		return
+	}
+
+	err = e.channel.QueueBind(
+		queue.name,
+		routingKey,
+		e.name,
+		config.NoWait,
+		config.bindArgs().toAmqpTable(),
+	)
+	if err != nil {
+		err = newError(err, "Failed to bind queue")
+		return
+	}
+
+	queue.bound = true
+	q = queue
+	return
+}
+
+// declareQueue declares a new queue using the exchange channel, without binding it to the exchange.
+//
+// When the queue name is empty, the server generates a name for the queue.
+func (e *amqpExchange) declareQueue(queueName, routingKey string, config QueueBindConfig) (q *amqpQueueBind, err error) {
+	args, err := config.declareArgs()
+	if err != nil {
+		err = newConfigError(err, "Invalid configuration for the %s queue", queueName)
+		return
+	}
+
+	var deadLetters Queue
+	if config.DeadLetter != nil {
+		deadLetters, err = e.declareDeadLetters(queueName, config)
+		if err != nil {
+			return
+		}
+
+		args["x-dead-letter-exchange"] = config.DeadLetter.exchangeName(queueName)
+		args["x-dead-letter-routing-key"] = queueName
+	}
+
+	declared, err := e.channel.QueueDeclare(
 		queueName,
 		config.Durable,
 		config.AutoDelete,
 		config.Exclusive,
 		config.NoWait,
-		config.Args.toAmqpTable(),
+		args.toAmqpTable(),
 	)
 	if err != nil {
-		err = fmt.Errorf("Failed to declare queue, %v", err)
+		err = newError(err, "Failed to declare queue")
 		return
 	}
 
-	err = e.channel.QueueBind(
-		queueName,
+	if declared.Name != "" {
+		queueName = declared.Name
+	}
+
+	q = &amqpQueueBind{
+		name:        queueName,
+		routingKey:  routingKey,
+		exchange:    e,
+		channel:     e.channel,
+		queueType:   config.Type,
+		config:      &config,
+		deadLetters: deadLetters,
+	}
+	return
+}
+
+// declareDeadLetters declares the dead-letter exchange and queue for a queue,
+// binding the dead-letter queue using the main queue name as the routing-key
+func (e *amqpExchange) declareDeadLetters(queueName string, config QueueBindConfig) (q Queue, err error) {
+	if queueName == "" {
+		err = newConfigError(nil, "A dead-letter topology can not be declared for a queue with a server-generated name")
+		return
+	}
+
+	dlxName := config.DeadLetter.exchangeName(queueName)
+	err = e.channel.ExchangeDeclare(
+		dlxName,
+		ExchangeTypeDirect.ToString(),
+		config.Durable,
+		false,
+		false,
+		config.NoWait,
+		nil,
+	)
+	if err != nil {
+		err = newError(err, "Failed to declare the %s dead-letter exchange", dlxName)
+		return
+	}
+
+	dlqConfig := QueueBindConfig{Durable: config.Durable}
+	if config.DeadLetter.Queue != nil {
+		dlqConfig = *config.DeadLetter.Queue
+	}
+
+	dlx := newExchange(e.client, dlxName, ExchangeTypeDirect, e.channel)
+	q, err = dlx.BindQueue(config.DeadLetter.queueName(queueName), queueName, dlqConfig)
+	if err != nil {
+		err = newError(err, "Failed to declare the %s dead-letter queue", config.DeadLetter.queueName(queueName))
+	}
+
+	return
+}
+
+// BindQueueHeaders declares a new queue on the exchange given a queue config and binds it to the headers exchange,
+// using the headers binding to match the message headers
+func (e *amqpExchange) BindQueueHeaders(queueName string, binding HeadersBinding, conf ...QueueBindConfig) (q Queue, err error) {
+	if e.kind != ExchangeTypeHeaders {
+		err = newConfigError(nil, "The %s exchange is not a headers exchange", e.name)
+		return
+	}
+
+	config := QueueBindConfig{}
+	if len(conf) > 0 {
+		config = conf[0]
+	}
+
+	args, err := binding.toTable()
+	if err != nil {
+		err = newConfigError(err, "Invalid headers binding for the %s queue", queueName)
+		return
+	}
+
+	for k, v := range config.BindArgs {
+		if _, ok := args[k]; !ok {
+			args[k] = v
+		}
+	}
+	config.BindArgs = args
+
+	return e.BindQueue(queueName, "", config)
+}
+
+// BindTo binds the exchange to a source exchange, so the messages published on the source exchange
+// that match the routing key are routed to this exchange
+func (e *amqpExchange) BindTo(source Exchange, routingKey string, conf ...ExchangeBindConfig) (err error) {
+	config := ExchangeBindConfig{}
+	if len(conf) > 0 {
+		config = conf[0]
+	}
+
+	err = e.channel.ExchangeBind(
+		e.name,
 		routingKey,
+		source.Name(),
+		config.NoWait,
+		config.Args.toAmqpTable(),
+	)
+	if err != nil {
+		err = newError(err, "Failed to bind the %s exchange to the %s exchange", e.name, source.Name())
+		return
+	}
+
+	e.bindings = append(e.bindings, ExchangeBinding{
+		Source:      source.Name(),
+		Destination: e.name,
+		RoutingKey:  routingKey,
+		Config:      config,
+	})
+	return
+}
+
+// UnbindFrom removes a binding between the exchange and a source exchange
+func (e *amqpExchange) UnbindFrom(source Exchange, routingKey string, conf ...ExchangeBindConfig) (err error) {
+	config := ExchangeBindConfig{}
+	if len(conf) > 0 {
+		config = conf[0]
+	}
+
+	err = e.channel.ExchangeUnbind(
 		e.name,
+		routingKey,
+		source.Name(),
 		config.NoWait,
 		config.Args.toAmqpTable(),
 	)
 	if err != nil {
-		err = fmt.Errorf("Failed to bind queue, %v", err)
+		err = newError(err, "Failed to unbind the %s exchange from the %s exchange", e.name, source.Name())
 		return
 	}
 
-	q = &amqpQueueBind{
-		name:     queueName,
-		exchange: e,
-		channel:  e.channel,
+	bindings := e.bindings[:0]
+	for _, b := range e.bindings {
+		if b.Source == source.Name() && b.RoutingKey == routingKey && sameArgs(b.Config.Args, config.Args) {
+			continue
+		}
+		bindings = append(bindings, b)
 	}
+	e.bindings = bindings
+	return
+}
+
+// Rebind binds the exchange again to the source exchanges of the bindings, or of every tracked binding when no binding is provided.
+// The provided bindings that are not tracked yet start to be tracked by the exchange.
+func (e *amqpExchange) Rebind(bindings ...ExchangeBinding) (err error) {
+	if len(bindings) == 0 {
+		bindings = append([]ExchangeBinding{}, e.bindings...)
+	}
+
+	for _, b := range bindings {
+		err = e.channel.ExchangeBind(
+			e.name,
+			b.RoutingKey,
+			b.Source,
+			b.Config.NoWait,
+			b.Config.Args.toAmqpTable(),
+		)
+		if err != nil {
+			err = newError(err, "Failed to bind the %s exchange to the %s exchange", e.name, b.Source)
+			return
+		}
+
+		if !e.tracksBinding(b) {
+			b.Destination = e.name
+			e.bindings = append(e.bindings, b)
+		}
+	}
+
 	return
 }
 
+// tracksBinding returns if the exchange tracks a binding to the same source exchange, with the same routing-key and arguments
+func (e *amqpExchange) tracksBinding(binding ExchangeBinding) bool {
+	for _, b := range e.bindings {
+		if b.Source == binding.Source && b.RoutingKey == binding.RoutingKey && sameArgs(b.Config.Args, binding.Config.Args) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Bindings returns the exchange-to-exchange bindings where this exchange is the destination
+func (e *amqpExchange) Bindings() []ExchangeBinding {
+	return e.bindings
+}
+
+// Unroutable returns the queue that captures the unroutable messages published on the exchange,
+// or nil if the exchange has no alternate exchange
+func (e *amqpExchange) Unroutable() Queue {
+	return e.unroutable
+}
+
 // Name returns the exchange name
 func (e *amqpExchange) Name() string {
 	return e.name
 }
 
+// Type returns the exchange type
+func (e *amqpExchange) Type() ExchangeType {
+	return e.kind
+}
+
 // PreHandleFuncs returns the pre handle funcs for the exchange
 func (e *amqpExchange) PreHandleFuncs() []PreHandleFunc {
 	return e.preHandleFuncs
@@ -84,6 +320,16 @@ func (e *amqpExchange) PostHandleFuncs() []PostHandleFunc {
 	return e.postHandleFuncs
 }
 
+// Middlewares returns the middlewares for the exchange
+func (e *amqpExchange) Middlewares() []Middleware {
+	return e.middlewares
+}
+
+// Use adds middlewares that will wrap the message handling of every queue on the exchange
+func (e *amqpExchange) Use(middlewares ...Middleware) {
+	e.middlewares = append(e.middlewares, middlewares...)
+}
+
 // Before adds functions that will be called in the exchange before the message handling
 func (e *amqpExchange) Before(funcs ...PreHandleFunc) {
 	e.preHandleFuncs = append(e.preHandleFuncs, funcs...)
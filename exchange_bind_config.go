@@ -0,0 +1,17 @@
+package amqp
+
+// ExchangeBindConfig represents the configuration for binding an exchange to another exchange
+type ExchangeBindConfig struct {
+	// When you bind an exchange with the "NoWait" option,
+	// it means that the method will not wait for a response from the server to confirm the binding.
+	// This can improve binding speed but comes with the trade-off that you won't receive an immediate response indicating success or failure.
+	//
+	// default: false
+	NoWait bool
+
+	// When binding an exchange in AMQP, you can include a set of optional arguments to customize the binding.
+	// These arguments are provided as a collection of key-value pairs, where the keys represent specific configuration options,
+	// and the values determine the settings for those options.
+	// Headers exchanges, for instance, use these arguments to match the message headers.
+	Args Table
+}
@@ -0,0 +1,16 @@
+package amqp
+
+// ExchangeBinding represents a binding between two exchanges.
+//
+// Messages published on the Source exchange that match the RoutingKey (and Args, for headers exchanges)
+// are routed to the Destination exchange.
+type ExchangeBinding struct {
+	// Source is the name of the exchange that the messages come from
+	Source string
+	// Destination is the name of the exchange that the messages are routed to
+	Destination string
+	// RoutingKey is the routing key used to bind the exchanges
+	RoutingKey string
+	// Config is the configuration used when binding the exchanges
+	Config ExchangeBindConfig
+}
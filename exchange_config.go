@@ -33,8 +33,46 @@ type ExchangeConfig struct {
 	// default: false
 	NoWait bool
 
+	// DelayedType defines how a delayed message exchange (ExchangeTypeDelayed) routes the messages after their delay is over.
+	// It is ignored by the other exchange types.
+	//
+	// default: ExchangeTypeDirect
+	DelayedType ExchangeType
+
+	// AlternateExchange defines an alternate exchange topology (exchange and capture queue) to be declared along with the exchange,
+	// so the messages that can not be routed to any queue are retained instead of being discarded.
+	// The capture queue can then be accessed using the exchange Unroutable function.
+	//
+	// default: nil (no alternate exchange is declared)
+	AlternateExchange *AlternateExchangeConfig
+
 	// When declaring an exchange in AMQP, you can include a set of optional arguments to customize the behavior of the exchange
 	// These arguments are provided as a collection of key-value pairs, where the keys represent specific configuration options,
 	// and the values determine the settings for those options.
 	Args Table
 }
+
+// alternateExchangeKey is the exchange argument used to define the exchange alternate exchange
+const alternateExchangeKey = "alternate-exchange"
+
+// delayedTypeKey is the exchange argument used to define how a delayed message exchange routes the messages
+const delayedTypeKey = "x-delayed-type"
+
+// delayedArgs returns the exchange arguments for a delayed message exchange, defining its routing type
+func (c ExchangeConfig) delayedArgs() Table {
+	if _, ok := c.Args[delayedTypeKey]; ok {
+		return c.Args
+	}
+
+	delayedType := c.DelayedType
+	if delayedType == "" {
+		delayedType = ExchangeTypeDirect
+	}
+
+	args := Table{delayedTypeKey: delayedType.ToString()}
+	for k, v := range c.Args {
+		args[k] = v
+	}
+
+	return args
+}
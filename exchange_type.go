@@ -23,8 +23,23 @@ const (
 	// Headers exchanges use message header attributes to determine message routing, rather than routing keys.
 	// The exchange will match headers against predefined criteria to determine which queues should receive the message.
 	ExchangeTypeHeaders = ExchangeType("headers")
+
+	// A delayed message exchange holds each message for the duration defined in its "x-delay" header before routing it.
+	// After the delay, the messages are routed according to the exchange DelayedType (direct, by default).
+	// It requires the rabbitmq_delayed_message_exchange plugin to be enabled on the server.
+	ExchangeTypeDelayed = ExchangeType("x-delayed-message")
+
+	// A consistent hash exchange routes each message to a single bound queue, chosen by hashing the message routing-key.
+	// The messages with the same routing-key are always routed to the same queue, while the bound queues receive a similar share of the messages.
+	// It requires the rabbitmq_consistent_hash_exchange plugin to be enabled on the server.
+	ExchangeTypeConsistentHash = ExchangeType("x-consistent-hash")
 )
 
+// defaultExchangeName is the name of the default exchange.
+//
+// The default exchange is a direct exchange, pre-declared by the server, where every queue is bound using its own name as the routing-key.
+const defaultExchangeName = ""
+
 // ToString returns the string notation of the exchange type
 func (t ExchangeType) ToString() string {
 	return string(t)
@@ -4,6 +4,10 @@ package amqp
 type HandleResponse struct {
 	// Nack defines if the message should NOT be acknowledged, and should be requeued (default: false)
 	Nack bool
+	// Reject defines if the message should be rejected without being requeued,
+	// so the server drops it or, if the queue has a dead-letter exchange, dead-letters it.
+	// When both Reject and Nack are true, the message is rejected (default: false)
+	Reject bool
 	// Err is the error that could have occurred during the message handling (default: nil)
 	Err error
 }
@@ -16,3 +16,48 @@ type PreHandleFunc func(*context.Context, *Delivery)
 //
 // It has access to a copy of the message handling context, the message, and the handle response.
 type PostHandleFunc func(context.Context, Delivery, HandleResponse)
+
+// Middleware represents a function that wraps a HandlerFunc, returning a new HandlerFunc.
+//
+// The middleware can run code before and after calling the wrapped handler function, or even skip calling it,
+// which makes it possible to short-circuit the handling, recover from panics, or measure the handling duration.
+type Middleware func(HandlerFunc) HandlerFunc
+
+// PreHandleMiddleware adapts pre handle functions into a middleware,
+// that calls the functions in order before calling the wrapped handler function
+func PreHandleMiddleware(funcs ...PreHandleFunc) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, d Delivery) HandleResponse {
+			for _, preFunc := range funcs {
+				preFunc(&ctx, &d)
+			}
+
+			return next(ctx, d)
+		}
+	}
+}
+
+// PostHandleMiddleware adapts post handle functions into a middleware,
+// that calls the functions in order after calling the wrapped handler function
+func PostHandleMiddleware(funcs ...PostHandleFunc) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, d Delivery) HandleResponse {
+			res := next(ctx, d)
+			for _, postFunc := range funcs {
+				postFunc(ctx, d, res)
+			}
+
+			return res
+		}
+	}
+}
+
+// chainMiddlewares wraps the handler function with the middlewares,
+// so the first middleware is the outermost one, and is the first to run
+func chainMiddlewares(handlerFn HandlerFunc, middlewares ...Middleware) HandlerFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handlerFn = middlewares[i](handlerFn)
+	}
+
+	return handlerFn
+}
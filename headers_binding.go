@@ -0,0 +1,150 @@
+package amqp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HeadersMatch represents how a headers exchange matches the message headers against the binding headers
+type HeadersMatch string
+
+const (
+	// HeadersMatchAll routes the message when all the binding headers match the message headers.
+	// Headers that start with "x-" are not considered when matching.
+	HeadersMatchAll = HeadersMatch("all")
+
+	// HeadersMatchAny routes the message when at least one of the binding headers match the message headers.
+	// Headers that start with "x-" are not considered when matching.
+	HeadersMatchAny = HeadersMatch("any")
+
+	// HeadersMatchAllWithX works like HeadersMatchAll, but also considers the headers that start with "x-"
+	HeadersMatchAllWithX = HeadersMatch("all-with-x")
+
+	// HeadersMatchAnyWithX works like HeadersMatchAny, but also considers the headers that start with "x-"
+	HeadersMatchAnyWithX = HeadersMatch("any-with-x")
+)
+
+// headersMatchKey is the binding argument used by the headers exchange to define the match semantics
+const headersMatchKey = "x-match"
+
+// ToString returns the string notation of the headers match
+func (m HeadersMatch) ToString() string {
+	return string(m)
+}
+
+// includesX returns if the headers match considers the headers that start with "x-"
+func (m HeadersMatch) includesX() bool {
+	return m == HeadersMatchAllWithX || m == HeadersMatchAnyWithX
+}
+
+// HeaderPredicate represents a condition over a single message header, used to bind a queue to a headers exchange
+type HeaderPredicate struct {
+	key   string
+	value any
+}
+
+// HeaderString returns a predicate that matches when the message header has the provided string value
+func HeaderString(key, value string) HeaderPredicate {
+	return HeaderPredicate{key, value}
+}
+
+// HeaderInt returns a predicate that matches when the message header has the provided integer value
+func HeaderInt(key string, value int64) HeaderPredicate {
+	return HeaderPredicate{key, value}
+}
+
+// HeaderFloat returns a predicate that matches when the message header has the provided float value
+func HeaderFloat(key string, value float64) HeaderPredicate {
+	return HeaderPredicate{key, value}
+}
+
+// HeaderBool returns a predicate that matches when the message header has the provided boolean value
+func HeaderBool(key string, value bool) HeaderPredicate {
+	return HeaderPredicate{key, value}
+}
+
+// HeaderTime returns a predicate that matches when the message header has the provided timestamp value
+func HeaderTime(key string, value time.Time) HeaderPredicate {
+	return HeaderPredicate{key, value}
+}
+
+// HeaderExists returns a predicate that matches when the message has the header, regardless of its value
+func HeaderExists(key string) HeaderPredicate {
+	return HeaderPredicate{key, nil}
+}
+
+// Key returns the header key that the predicate checks
+func (p HeaderPredicate) Key() string {
+	return p.key
+}
+
+// HeadersBinding represents the criteria used to bind a queue to a headers exchange
+type HeadersBinding struct {
+	// Match defines how the predicates are combined to match a message.
+	//
+	// default: HeadersMatchAll
+	Match HeadersMatch
+
+	// Predicates are the conditions over the message headers
+	Predicates []HeaderPredicate
+}
+
+// MatchAllHeaders returns a headers binding that routes the message when all the predicates match
+func MatchAllHeaders(predicates ...HeaderPredicate) HeadersBinding {
+	return HeadersBinding{
+		Match:      HeadersMatchAll,
+		Predicates: predicates,
+	}
+}
+
+// MatchAnyHeaders returns a headers binding that routes the message when at least one of the predicates match
+func MatchAnyHeaders(predicates ...HeaderPredicate) HeadersBinding {
+	return HeadersBinding{
+		Match:      HeadersMatchAny,
+		Predicates: predicates,
+	}
+}
+
+// toTable validates the headers binding and returns the binding arguments that represent it
+func (b HeadersBinding) toTable() (t Table, err error) {
+	match := b.Match
+	if match == "" {
+		match = HeadersMatchAll
+	}
+
+	switch match {
+	case HeadersMatchAll, HeadersMatchAny, HeadersMatchAllWithX, HeadersMatchAnyWithX:
+	default:
+		err = fmt.Errorf("Invalid headers match %q", match)
+		return
+	}
+
+	if len(b.Predicates) == 0 {
+		err = errors.New("A headers binding needs at least one header predicate")
+		return
+	}
+
+	t = Table{headersMatchKey: match.ToString()}
+	for _, p := range b.Predicates {
+		if p.key == "" || p.key == headersMatchKey {
+			err = fmt.Errorf("Invalid header predicate key %q", p.key)
+			return
+		}
+
+		if strings.HasPrefix(p.key, "x-") && !match.includesX() {
+			err = fmt.Errorf("The %q header is ignored by the %q match, use the %q or %q match instead", p.key, match, HeadersMatchAllWithX, HeadersMatchAnyWithX)
+			return
+		}
+
+		if _, ok := t[p.key]; ok {
+			err = fmt.Errorf("The %q header has more than one predicate", p.key)
+			return
+		}
+
+		t[p.key] = p.value
+	}
+
+	return
+}
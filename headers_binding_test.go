@@ -0,0 +1,96 @@
+package amqp
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHeadersBindingToTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		binding HeadersBinding
+		want    Table
+		wantErr bool
+	}{
+		{
+			name:    "empty match defaults to all",
+			binding: HeadersBinding{Predicates: []HeaderPredicate{HeaderString("type", "order")}},
+			want:    Table{"x-match": "all", "type": "order"},
+		},
+		{
+			name:    "match all",
+			binding: MatchAllHeaders(HeaderString("type", "order"), HeaderInt("version", 2)),
+			want:    Table{"x-match": "all", "type": "order", "version": int64(2)},
+		},
+		{
+			name:    "match any",
+			binding: MatchAnyHeaders(HeaderBool("urgent", true), HeaderExists("trace")),
+			want:    Table{"x-match": "any", "urgent": true, "trace": nil},
+		},
+		{
+			name:    "x- key under all",
+			binding: MatchAllHeaders(HeaderString("x-tenant", "acme")),
+			wantErr: true,
+		},
+		{
+			name:    "x- key under any",
+			binding: MatchAnyHeaders(HeaderString("x-tenant", "acme")),
+			wantErr: true,
+		},
+		{
+			name:    "x- key under all-with-x",
+			binding: HeadersBinding{Match: HeadersMatchAllWithX, Predicates: []HeaderPredicate{HeaderString("x-tenant", "acme")}},
+			want:    Table{"x-match": "all-with-x", "x-tenant": "acme"},
+		},
+		{
+			name:    "x- key under any-with-x",
+			binding: HeadersBinding{Match: HeadersMatchAnyWithX, Predicates: []HeaderPredicate{HeaderString("x-tenant", "acme"), HeaderFloat("ratio", 0.5)}},
+			want:    Table{"x-match": "any-with-x", "x-tenant": "acme", "ratio": 0.5},
+		},
+		{
+			name:    "invalid match",
+			binding: HeadersBinding{Match: HeadersMatch("some"), Predicates: []HeaderPredicate{HeaderString("type", "order")}},
+			wantErr: true,
+		},
+		{
+			name:    "no predicates",
+			binding: MatchAllHeaders(),
+			wantErr: true,
+		},
+		{
+			name:    "empty key",
+			binding: MatchAllHeaders(HeaderString("", "order")),
+			wantErr: true,
+		},
+		{
+			name:    "x-match key",
+			binding: HeadersBinding{Match: HeadersMatchAllWithX, Predicates: []HeaderPredicate{HeaderString("x-match", "any")}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicated key",
+			binding: MatchAllHeaders(HeaderString("type", "order"), HeaderString("type", "invoice")),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.binding.toTable()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("toTable() = %v, want an error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("toTable() returned an unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("toTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package amqp
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Health represents the health of the client, its connection, exchanges, publishers and consumers
+type Health struct {
+	// Live defines if the client is alive, meaning its connection is open
+	Live bool `json:"live"`
+	// Ready defines if the client is ready, meaning it is alive, the connection is not blocked,
+	// the exchange and publisher channels are open and the consumers are running
+	Ready bool `json:"ready"`
+	// Connection is the health of the client connection
+	Connection ConnectionHealth `json:"connection"`
+	// Exchanges are the health of the exchanges started through the client
+	Exchanges []ChannelHealth `json:"exchanges"`
+	// Publishers are the health of the publishers created through the client
+	Publishers []ChannelHealth `json:"publishers"`
+	// Consumers are the health of the consumers subscribed through the client
+	Consumers []ConsumerHealth `json:"consumers"`
+}
+
+// ConnectionHealth represents the health of the client connection
+type ConnectionHealth struct {
+	// Open defines if the connection is open
+	Open bool `json:"open"`
+	// Blocked defines if the server blocked the connection from publishing messages, i.e. due to a memory or disk alarm
+	Blocked bool `json:"blocked"`
+	// BlockedReason is the reason sent by the server when it blocked the connection
+	BlockedReason string `json:"blocked_reason,omitempty"`
+}
+
+// ChannelHealth represents the health of the channel of an exchange or publisher
+type ChannelHealth struct {
+	// Exchange is the name of the exchange
+	Exchange string `json:"exchange"`
+	// Queue is the name of the queue, for publishers created using CreateQueuePublisher
+	Queue string `json:"queue,omitempty"`
+	// Open defines if the channel is open
+	Open bool `json:"open"`
+}
+
+// ConsumerHealth represents the health of a consumer
+type ConsumerHealth struct {
+	// Name is the consumer name (tag) on the server
+	Name string `json:"name"`
+	// Queue is the name of the queue that the consumer is subscribed to
+	Queue string `json:"queue"`
+	// Running defines if the consumer is still receiving the queue messages
+	Running bool `json:"running"`
+	// LastDelivery is when the consumer received its last message, or nil if it did not receive any message
+	LastDelivery *time.Time `json:"last_delivery,omitempty"`
+}
+
+// healthRegistry keeps the exchanges, publishers and consumers reported by the client health
+type healthRegistry struct {
+	mu         sync.Mutex
+	exchanges  []*amqpExchange
+	publishers []*amqpPublisher
+	consumers  []*consumer
+}
+
+// addExchange adds an exchange to the health registry
+func (r *healthRegistry) addExchange(e *amqpExchange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.exchanges = append(r.exchanges, e)
+}
+
+// addPublisher adds a publisher to the health registry
+func (r *healthRegistry) addPublisher(p *amqpPublisher) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.publishers = append(r.publishers, p)
+}
+
+// addConsumer adds a consumer to the health registry
+func (r *healthRegistry) addConsumer(c *consumer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.consumers = append(r.consumers, c)
+}
+
+// removeConsumer removes a consumer from the health registry, i.e. when it was replaced by a resubscription
+func (r *healthRegistry) removeConsumer(c *consumer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, registered := range r.consumers {
+		if registered == c {
+			r.consumers = append(r.consumers[:i], r.consumers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Health returns the health of the client, its connection, exchanges, publishers and consumers
+func (c *client) Health() (h Health) {
+	h.Connection.Open = c.conn != nil && !c.conn.IsClosed()
+	h.Connection.Blocked, h.Connection.BlockedReason = c.blockedState()
+	h.Exchanges = []ChannelHealth{}
+	h.Publishers = []ChannelHealth{}
+	h.Consumers = []ConsumerHealth{}
+
+	h.Live = h.Connection.Open
+	h.Ready = h.Live && !h.Connection.Blocked
+
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+
+	for _, e := range c.health.exchanges {
+		open := !e.channel.IsClosed()
+		h.Ready = h.Ready && open
+		h.Exchanges = append(h.Exchanges, ChannelHealth{
+			Exchange: e.name,
+			Open:     open,
+		})
+	}
+
+	for _, p := range c.health.publishers {
+		open := !p.channel.IsClosed()
+		h.Ready = h.Ready && open
+		h.Publishers = append(h.Publishers, ChannelHealth{
+			Exchange: p.exchangeName,
+			Queue:    p.queueName,
+			Open:     open,
+		})
+	}
+
+	for _, cons := range c.health.consumers {
+		running := cons.running.Load()
+		h.Ready = h.Ready && running
+
+		consumerHealth := ConsumerHealth{
+			Name:    cons.name,
+			Queue:   cons.queue.name,
+			Running: running,
+		}
+		if last := cons.lastDelivery.Load(); last != 0 {
+			lastDelivery := time.Unix(0, last)
+			consumerHealth.LastDelivery = &lastDelivery
+		}
+		h.Consumers = append(h.Consumers, consumerHealth)
+	}
+
+	return
+}
+
+// LivenessHandler returns a http handler that responds with the client health as JSON,
+// with the 200 status code when the client is alive, or 503 when it is not
+func (c *client) LivenessHandler() http.Handler {
+	return healthHandler{client: c}
+}
+
+// ReadinessHandler returns a http handler that responds with the client health as JSON,
+// with the 200 status code when the client is ready, or 503 when it is not
+func (c *client) ReadinessHandler() http.Handler {
+	return healthHandler{client: c, readiness: true}
+}
+
+// healthHandler is a http handler that responds with the client health
+type healthHandler struct {
+	client *client
+
+	// readiness defines if the handler checks the client readiness instead of its liveness
+	readiness bool
+}
+
+// ServeHTTP responds with the client health as JSON
+func (h healthHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	health := h.client.Health()
+
+	healthy := health.Live
+	if h.readiness {
+		healthy = health.Ready
+	}
+
+	status := http.StatusOK
+	if !healthy {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(health)
+}
@@ -0,0 +1,59 @@
+package amqp
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+	"time"
+)
+
+// crockfordBase32 is the alphabet used to encode ULIDs
+const crockfordBase32 = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// IDGenerator represents a function that generates unique message ids
+type IDGenerator func() string
+
+// NewUUIDv4 generates a random (version 4) UUID, in its canonical string format
+func NewUUIDv4() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	var s [36]byte
+	hex.Encode(s[0:8], b[0:4])
+	s[8] = '-'
+	hex.Encode(s[9:13], b[4:6])
+	s[13] = '-'
+	hex.Encode(s[14:18], b[6:8])
+	s[18] = '-'
+	hex.Encode(s[19:23], b[8:10])
+	s[23] = '-'
+	hex.Encode(s[24:], b[10:])
+
+	return string(s[:])
+}
+
+// NewULID generates a ULID, a lexicographically sortable unique id made of a millisecond timestamp and random bits,
+// in its canonical 26 characters string format
+func NewULID() string {
+	var b [16]byte
+	var ts [8]byte
+	binary.BigEndian.PutUint64(ts[:], uint64(time.Now().UnixMilli()))
+	copy(b[:6], ts[2:])
+	_, _ = rand.Read(b[6:])
+
+	n := new(big.Int).SetBytes(b[:])
+	base := big.NewInt(32)
+	mod := new(big.Int)
+
+	var s [26]byte
+	for i := len(s) - 1; i >= 0; i-- {
+		n.DivMod(n, base, mod)
+		s[i] = crockfordBase32[mod.Int64()]
+	}
+
+	return string(s[:])
+}
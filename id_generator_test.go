@@ -0,0 +1,73 @@
+package amqp
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIDGenerators(t *testing.T) {
+	tests := []struct {
+		name     string
+		generate IDGenerator
+		format   *regexp.Regexp
+	}{
+		{
+			name:     "UUIDv4",
+			generate: NewUUIDv4,
+			format:   regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+		},
+		{
+			name:     "ULID",
+			generate: NewULID,
+			format:   regexp.MustCompile(`^[0-7][` + crockfordBase32 + `]{25}$`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen := map[string]bool{}
+			for i := 0; i < 1000; i++ {
+				id := tt.generate()
+				if !tt.format.MatchString(id) {
+					t.Fatalf("%q does not match the %s format %s", id, tt.name, tt.format)
+				}
+
+				if seen[id] {
+					t.Fatalf("%q was generated twice", id)
+				}
+				seen[id] = true
+			}
+		})
+	}
+}
+
+func TestNewULIDTimestamp(t *testing.T) {
+	before := time.Now().UnixMilli()
+	id := NewULID()
+	after := time.Now().UnixMilli()
+
+	if len(id) != 26 {
+		t.Fatalf("len(%q) = %d, want 26", id, len(id))
+	}
+
+	if strings.ContainsAny(id, "ILOU") {
+		t.Fatalf("%q has characters outside of the Crockford base32 alphabet", id)
+	}
+
+	// the first 10 characters encode the 48 bits millisecond timestamp
+	var ms int64
+	for _, c := range id[:10] {
+		ms = ms*32 + int64(strings.IndexRune(crockfordBase32, c))
+	}
+
+	if ms < before || ms > after {
+		t.Errorf("%q timestamp = %d, want between %d and %d", id, ms, before, after)
+	}
+
+	time.Sleep(2 * time.Millisecond)
+	if next := NewULID(); next <= id {
+		t.Errorf("NewULID() = %q generated after %q, want it sorted after", next, id)
+	}
+}
@@ -1,6 +1,10 @@
 package amqp
 
 import (
+	"context"
+	"net/http"
+	"time"
+
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
@@ -19,6 +23,36 @@ type Client interface {
 	// Ping checks if the AMQP connection is active
 	Ping() error
 
+	// Blocked returns if the server blocked the connection from publishing messages, i.e. due to a memory or disk alarm
+	Blocked() bool
+
+	// Health returns the health of the client: the connection state, if the connection is blocked,
+	// the channel state of every exchange and publisher, and the consumers liveness
+	Health() Health
+
+	// LivenessHandler returns a http handler that responds with the client health as JSON,
+	// with the 200 status code when the connection is open, or 503 when it is not.
+	//
+	// It can be used as a Kubernetes liveness probe.
+	LivenessHandler() http.Handler
+
+	// ReadinessHandler returns a http handler that responds with the client health as JSON,
+	// with the 200 status code when the connection is open and not blocked, the exchange and publisher channels are open,
+	// and the consumers are running, or 503 when it is not.
+	//
+	// It can be used as a Kubernetes readiness probe.
+	ReadinessHandler() http.Handler
+
+	// Use adds middlewares that will wrap the message handling of every queue consumed through the client.
+	//
+	// The client middlewares are the outermost ones, running before the exchange, queue and consumer middlewares.
+	Use(middlewares ...Middleware)
+
+	// Intercept adds interceptors that will wrap the message publishing of every publisher created through the client.
+	//
+	// The client interceptors are the outermost ones, running before the publisher interceptors.
+	Intercept(interceptors ...PublishInterceptor)
+
 	// StartExchange starts a AMQP exchange with its own channel and returns the exchange as an entity
 	StartExchange(exchangeName string, exchangeType ExchangeType, conf ...ExchangeConfig) (Exchange, error)
 
@@ -29,6 +63,36 @@ type Client interface {
 	//
 	// The NoWait flag should be used when your server does not support publishers in confirmation mode, or when you specifically want the publisher to be asynchronous.
 	CreatePublisher(exchangeName string, NoWait ...bool) (Publisher, error)
+
+	// CreatePublisherWithConfig creates a new publisher with its own channel to publish messages on an exchange,
+	// given the exchange name and the publisher configuration.
+	CreatePublisherWithConfig(exchangeName string, conf PublisherConfig) (Publisher, error)
+
+	// CreateQueuePublisher creates a new publisher with its own channel to publish messages directly on a queue, given the queue name.
+	//
+	// The messages are published on the default exchange, using the queue name as the routing-key,
+	// so the routing-key provided when publishing a message is ignored.
+	//
+	// The optional NoWait flag works the same way as in CreatePublisher.
+	CreateQueuePublisher(queueName string, NoWait ...bool) (Publisher, error)
+
+	// CreateQueuePublisherWithConfig creates a new publisher with its own channel to publish messages directly on a queue,
+	// given the queue name and the publisher configuration.
+	//
+	// It works the same way as CreateQueuePublisher, using the publisher configuration like CreatePublisherWithConfig.
+	CreateQueuePublisherWithConfig(queueName string, conf PublisherConfig) (Publisher, error)
+
+	// DeclareQueue declares a queue with its own channel, without binding it to an exchange, and returns the queue as an entity.
+	//
+	// Every queue is bound to the default exchange using its own name as the routing-key,
+	// so the queue can receive messages published with a publisher created using CreateQueuePublisher.
+	// When the queue name is empty, the server generates a name for the queue.
+	DeclareQueue(queueName string, conf ...QueueBindConfig) (Queue, error)
+
+	// Queue returns an existing queue with its own channel as an entity, so it can be consumed.
+	//
+	// It returns an error if the queue does not exist on the server.
+	Queue(queueName string) (Queue, error)
 }
 
 // Exchange represents a AMQP message exchange
@@ -37,18 +101,64 @@ type Exchange interface {
 	// BindQueue declares a new queue on the exchange given a queue config and binds it to the exchange
 	BindQueue(queueName, routingKey string, conf ...QueueBindConfig) (Queue, error)
 
+	// BindQueueHeaders declares a new queue on the exchange given a queue config and binds it to the exchange,
+	// using the headers binding to match the message headers instead of a routing-key.
+	//
+	// It can only be used on headers exchanges.
+	BindQueueHeaders(queueName string, binding HeadersBinding, conf ...QueueBindConfig) (Queue, error)
+
+	// BindPartitions declares a set of partition queues on the exchange given a queue config, named "<queueName>.<index>", and binds them to the exchange.
+	//
+	// Each message is routed to a single partition, and every message of the same entity is routed to the same partition,
+	// so the messages of an entity can be processed in order while the partitions are consumed in parallel.
+	//
+	// On a consistent hash exchange, the server chooses the partition by hashing the message routing-key.
+	// On direct and topic exchanges, the library computes the partition, so the messages must be published using the PartitionedQueue RoutingKey function.
+	BindPartitions(queueName string, partitions int, conf ...QueueBindConfig) (PartitionedQueue, error)
+
+	// BindTo binds the exchange to a source exchange,
+	// so the messages published on the source exchange that match the routing key are routed to this exchange.
+	//
+	// The binding is tracked by the exchange, so it can be replayed using Rebind.
+	BindTo(source Exchange, routingKey string, conf ...ExchangeBindConfig) error
+	// UnbindFrom removes a binding between the exchange and a source exchange, previously created with BindTo
+	// using the same routing-key and arguments
+	UnbindFrom(source Exchange, routingKey string, conf ...ExchangeBindConfig) error
+	// Rebind binds the exchange again to the source exchanges of the bindings, or of every tracked binding when no binding is provided.
+	//
+	// The library does not replay the bindings by itself: after the exchange is started again (i.e. on a new connection),
+	// the bindings returned by the previous exchange Bindings function can be replayed by passing them to Rebind.
+	Rebind(bindings ...ExchangeBinding) error
+	// Bindings returns the exchange-to-exchange bindings where this exchange is the destination
+	Bindings() []ExchangeBinding
+
+	// Unroutable returns the queue that captures the messages published on the exchange that could not be routed to any queue,
+	// declared using the ExchangeConfig AlternateExchange field.
+	//
+	// The queue can be consumed like any other queue. It returns nil if the exchange has no alternate exchange.
+	Unroutable() Queue
+
 	// Before adds functions that will be called in the exchange before the message handling
 	Before(funcs ...PreHandleFunc)
 
 	// After adds functions that will be called in the exchange after the message handling
 	After(funcs ...PostHandleFunc)
 
+	// Use adds middlewares that will wrap the message handling of every queue on the exchange.
+	//
+	// The exchange middlewares run after the client middlewares, and before the queue and consumer middlewares.
+	Use(middlewares ...Middleware)
+
 	// Name returns the exchange name
 	Name() string
+	// Type returns the exchange type
+	Type() ExchangeType
 	// PreHandleFuncs returns the pre handle funcs for the exchange
 	PreHandleFuncs() []PreHandleFunc
 	// PostHandleFuncs returns the post handle funcs for the exchange
 	PostHandleFuncs() []PostHandleFunc
+	// Middlewares returns the middlewares for the exchange
+	Middlewares() []Middleware
 }
 
 // Queue represents a AMQP queue
@@ -59,22 +169,75 @@ type Queue interface {
 	// and handles them with the provided handler function
 	Consume(handlerFn HandlerFunc, conf ...ConsumeConfig) error
 
+	// Inspect returns the current state of the queue, like the number of ready messages and consumers.
+	//
+	// Inspect, Purge and Delete run on their own short-lived channel,
+	// so an error on them (i.e. when the queue does not exist on the server) does not close the queue channel.
+	Inspect() (QueueInfo, error)
+	// Purge removes all the ready messages from the queue, and returns how many messages were removed.
+	// The messages that were delivered and are waiting for an acknowledgment are not removed.
+	Purge() (int, error)
+	// Delete deletes the queue from the server, and returns how many messages were removed along with it.
+	//
+	// When ifUnused is true, the queue is only deleted if it has no consumers.
+	// When ifEmpty is true, the queue is only deleted if it has no messages.
+	// If the condition is not met, the server returns an error matching ErrPreconditionFailed.
+	Delete(ifUnused, ifEmpty bool) (int, error)
+	// WaitUntilEmpty inspects the queue on every interval until it has no ready messages.
+	//
+	// It returns an error if the interval is not positive, if the queue could not be inspected, or if the context is done before the queue is empty.
+	WaitUntilEmpty(ctx context.Context, interval time.Duration) error
+
 	// Before adds functions that will be called in the queue before the message handling
 	Before(funcs ...PreHandleFunc)
 
 	// After adds functions that will be called in the queue after the message handling
 	After(funcs ...PostHandleFunc)
 
+	// Use adds middlewares that will wrap the message handling of the queue.
+	//
+	// The queue middlewares run after the client and exchange middlewares, and before the consumer middlewares.
+	Use(middlewares ...Middleware)
+
+	// OnError adds handlers for the errors returned in the handle responses of the queue.
+	//
+	// The handlers receive the message, the error, the delivery attempt and how the message was acknowledged,
+	// and are called before the client ErrorHandler.
+	OnError(handlers ...ErrorHandler)
+
 	// Name returns the queue name
 	Name() string
 	// Exchange returns the Exchange that the queue is on
 	Exchange() Exchange
 	// RoutingKey returns the queue routing-key that was used to bind to the exchange
 	RoutingKey() string
+	// DeadLetters returns the dead-letter queue declared along with the queue, using the QueueBindConfig DeadLetter field.
+	//
+	// The dead-letter queue can be consumed like any other queue. It returns nil if the queue has no dead-letter topology.
+	DeadLetters() Queue
 	// PreHandleFuncs returns the pre handle funcs for the queue
 	PreHandleFuncs() []PreHandleFunc
 	// PostHandleFuncs returns the post handle funcs for the queue
 	PostHandleFuncs() []PostHandleFunc
+	// Middlewares returns the middlewares for the queue
+	Middlewares() []Middleware
+}
+
+// PartitionedQueue represents a set of AMQP queues where each message is routed to a single partition
+type PartitionedQueue interface {
+	// Consume subscribes a consumer in each partition assigned to the application instance, to handle the messages.
+	//
+	// Each partition is consumed by a single consumer that handles its messages in order, one at a time.
+	Consume(handlerFn HandlerFunc, conf ...PartitionConsumeConfig) error
+
+	// RoutingKey returns the routing-key that must be used to publish the messages of an entity (i.e. an order id),
+	// so every message of the same entity is routed to the same partition
+	RoutingKey(entityKey string) string
+
+	// Name returns the partitioned queue name
+	Name() string
+	// Partitions returns the partition queues, ordered by their index
+	Partitions() []Queue
 }
 
 // Publisher represents a AMQP message publisher
@@ -93,4 +256,17 @@ type Publisher interface {
 	// It is important to note that the message publishing, by default, is asynchronous.
 	// However, you can make it synchronous by setting the WaitConfirmation flag from the PublishConfig as true.
 	PublishJSON(payload any, key string, conf ...PublishConfig) error
+
+	// PublishWithContext works like Publish, but receives a context,
+	// which is passed through the publish interceptors and used to cancel the publishing and the wait for its confirmation.
+	PublishWithContext(ctx context.Context, payload []byte, key string, conf ...PublishConfig) error
+
+	// PublishJSONWithContext works like PublishJSON, but receives a context,
+	// which is passed through the publish interceptors and used to cancel the publishing and the wait for its confirmation.
+	PublishJSONWithContext(ctx context.Context, payload any, key string, conf ...PublishConfig) error
+
+	// Intercept adds interceptors that will wrap the message publishing of the publisher.
+	//
+	// The publisher interceptors run after the client interceptors, in the order they were added.
+	Intercept(interceptors ...PublishInterceptor)
 }
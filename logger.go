@@ -0,0 +1,51 @@
+package amqp
+
+import (
+	"context"
+	"log/slog"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// discardHandler is a slog handler that discards the log records, used when the client has no logger
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// discardLogger is the logger used when the client has no logger
+var discardLogger = slog.New(discardHandler{})
+
+// logger returns the client logger, or a logger that discards the logs if the client has none
+func (c *client) logger() *slog.Logger {
+	if c == nil || c.config.Logger == nil {
+		return discardLogger
+	}
+
+	return c.config.Logger
+}
+
+// openChannel opens a new channel on the client connection, logging when the channel is closed,
+// with the provided attributes identifying what the channel is used for
+func (c *client) openChannel(attrs ...any) (ch *amqp.Channel, err error) {
+	ch, err = c.conn.Channel()
+	if err != nil {
+		return
+	}
+
+	logger := c.logger().With(attrs...)
+	closes := ch.NotifyClose(make(chan *amqp.Error, 1))
+	go func() {
+		closeErr, ok := <-closes
+		if ok && closeErr != nil {
+			logger.Warn("AMQP channel closed by the server", "error", closeErr)
+			return
+		}
+
+		logger.Debug("AMQP channel closed")
+	}()
+
+	return
+}
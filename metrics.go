@@ -0,0 +1,59 @@
+package amqp
+
+import (
+	"time"
+)
+
+// PublishOutcome represents the result of a message publishing
+type PublishOutcome string
+
+const (
+	// PublishOutcomePublished means the message was published without waiting for the server confirmation
+	PublishOutcomePublished = PublishOutcome("published")
+	// PublishOutcomeConfirmed means the message was published and acknowledged by the server
+	PublishOutcomeConfirmed = PublishOutcome("confirmed")
+	// PublishOutcomeNacked means the message was published, but the server did not acknowledge it
+	PublishOutcomeNacked = PublishOutcome("nacked")
+	// PublishOutcomeFailed means the message could not be published, or the wait for its confirmation failed
+	PublishOutcomeFailed = PublishOutcome("failed")
+)
+
+// ToString returns the string notation of the publish outcome
+func (o PublishOutcome) ToString() string {
+	return string(o)
+}
+
+// Metrics represents a collector of the client metrics.
+//
+// The functions are called synchronously while publishing and consuming messages, so they should not block.
+type Metrics interface {
+	// MessagePublished is called after a message publishing on an exchange,
+	// with its outcome and how long it took, including the wait for the server confirmation
+	MessagePublished(exchange string, outcome PublishOutcome, duration time.Duration)
+
+	// MessageReturned is called when the server returns a message published on an exchange as mandatory or immediate,
+	// because it could not be routed or delivered
+	MessageReturned(exchange string)
+
+	// HandlerStarted is called before a message from a queue is handled
+	HandlerStarted(queue string)
+
+	// HandlerFinished is called after a message from a queue is handled, with how long the handling took
+	HandlerFinished(queue string, duration time.Duration)
+
+	// DeliveryConsumed is called after a message from a queue is acknowledged, with how it was acknowledged
+	DeliveryConsumed(queue string, outcome DeliveryOutcome)
+
+	// ConnectionStateChanged is called when the client connection is opened or closed
+	ConnectionStateChanged(open bool)
+}
+
+// noopMetrics is a metrics collector that discards the metrics, used when the client has no metrics
+type noopMetrics struct{}
+
+func (noopMetrics) MessagePublished(string, PublishOutcome, time.Duration) {}
+func (noopMetrics) MessageReturned(string)                                 {}
+func (noopMetrics) HandlerStarted(string)                                  {}
+func (noopMetrics) HandlerFinished(string, time.Duration)                  {}
+func (noopMetrics) DeliveryConsumed(string, DeliveryOutcome)               {}
+func (noopMetrics) ConnectionStateChanged(bool)                            {}
@@ -0,0 +1,96 @@
+package amqp
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// OffsetStore represents a storage for the last offset processed by each stream consumer,
+// so the consumer can resume reading the stream from where it stopped
+type OffsetStore interface {
+	// Load returns the last offset stored for the consumer.
+	// When there is no offset stored for the consumer, found is false.
+	Load(consumerName string) (offset int64, found bool, err error)
+
+	// Save stores the last offset processed by the consumer
+	Save(consumerName string, offset int64) error
+}
+
+// DefaultOffsetStore is the offset store used by the stream consumers that do not define their own offset store.
+//
+// It stores the offsets in the ".amqp-offsets" directory, relative to the application working directory.
+var DefaultOffsetStore OffsetStore = NewFileOffsetStore(".amqp-offsets")
+
+// fileOffsetStore represents an offset store that keeps the offset of each consumer in its own file
+type fileOffsetStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewFileOffsetStore returns an offset store that keeps the offset of each consumer in its own file, inside the provided directory.
+//
+// The directory is created when the first offset is saved.
+func NewFileOffsetStore(dir string) OffsetStore {
+	return &fileOffsetStore{dir: dir}
+}
+
+// Load returns the last offset stored for the consumer
+func (s *fileOffsetStore) Load(consumerName string) (offset int64, found bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	content, err := os.ReadFile(s.path(consumerName))
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+		return
+	}
+	if err != nil {
+		err = fmt.Errorf("Failed to read the %s consumer offset, %w", consumerName, err)
+		return
+	}
+
+	offset, err = strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("Invalid offset stored for the %s consumer, %w", consumerName, err)
+		return
+	}
+
+	found = true
+	return
+}
+
+// Save stores the last offset processed by the consumer, replacing the file atomically
+func (s *fileOffsetStore) Save(consumerName string, offset int64) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err = os.MkdirAll(s.dir, 0o755)
+	if err != nil {
+		return fmt.Errorf("Failed to create the offsets directory, %w", err)
+	}
+
+	path := s.path(consumerName)
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, []byte(strconv.FormatInt(offset, 10)), 0o644)
+	if err != nil {
+		return fmt.Errorf("Failed to write the %s consumer offset, %w", consumerName, err)
+	}
+
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return fmt.Errorf("Failed to write the %s consumer offset, %w", consumerName, err)
+	}
+
+	return
+}
+
+// path returns the file path for a consumer offset, escaping the consumer name so it is a valid file name
+func (s *fileOffsetStore) path(consumerName string) string {
+	return filepath.Join(s.dir, url.PathEscape(consumerName)+".offset")
+}
@@ -0,0 +1,18 @@
+package amqp
+
+import (
+	"fmt"
+)
+
+// PanicError represents a panic that was recovered while handling a message
+type PanicError struct {
+	// Value is the value the panic was called with
+	Value any
+	// Stack is the stack trace of the goroutine when the panic was recovered
+	Stack []byte
+}
+
+// Error returns the error message
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("Panic while handling the message, %v", e.Value)
+}
@@ -0,0 +1,33 @@
+package amqp
+
+// defaultPartitionPrefetchCount is the prefetch count used to consume each partition when the ConsumeConfig does not define one,
+// so a nacked message is requeued before the next messages of the partition are delivered
+const defaultPartitionPrefetchCount = 1
+
+// PartitionConsumeConfig represents the configuration that can be provided when consuming a partitioned queue
+type PartitionConsumeConfig struct {
+	// Instance is the index of the application instance that is consuming the partitioned queue, starting at 0.
+	// Each instance consumes the partitions whose index modulo Instances is equal to Instance.
+	//
+	// default: 0
+	Instance int
+
+	// Instances is the total number of application instances that consume the partitioned queue.
+	// Every instance must use the same number of instances, so each partition is consumed by a single instance.
+	//
+	// default: 1 (a single instance consumes every partition)
+	Instances int
+
+	// ConsumeConfig is the configuration used to consume each partition.
+	// When a consumer name is provided, the partition index is appended to it.
+	//
+	// When no PrefetchCount is provided, each partition is consumed with a prefetch count of 1, so the messages are handled in order
+	// even when a message is nacked and requeued. A higher prefetch count handles the messages faster,
+	// but a requeued message is redelivered after the messages already delivered behind it.
+	// The prefetch count is applied to the exchange channel, shared by the partitions and the other queues of the exchange,
+	// so it also affects the consumers started on the exchange queues afterwards without a PrefetchCount.
+	//
+	// Setting the Exclusive flag as true makes the server refuse a second consumer on the same partition,
+	// guaranteeing a single worker per partition even if the instances are misconfigured.
+	ConsumeConfig ConsumeConfig
+}
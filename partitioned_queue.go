@@ -0,0 +1,127 @@
+package amqp
+
+import (
+	"fmt"
+	"hash/fnv"
+)
+
+// consistentHashWeight is the routing-key used to bind the partitions to a consistent hash exchange.
+// Every partition has the same weight, so they receive a similar share of the messages.
+const consistentHashWeight = "1"
+
+// amqpPartitionedQueue represents a set of queues bound to an exchange, where each message is routed to a single partition
+type amqpPartitionedQueue struct {
+	name string
+
+	// partitions are the partition queues, ordered by their index
+	partitions []Queue
+
+	// hashed defines if the partitions are chosen by a consistent hash exchange,
+	// instead of the modulo computed by the library
+	hashed bool
+}
+
+// BindPartitions declares a set of partition queues on the exchange, given a queue config, and binds them to the exchange
+func (e *amqpExchange) BindPartitions(queueName string, partitions int, conf ...QueueBindConfig) (pq PartitionedQueue, err error) {
+	if partitions < 1 {
+		err = newConfigError(nil, "The %s partitioned queue needs at least one partition", queueName)
+		return
+	}
+
+	hashed := e.kind == ExchangeTypeConsistentHash
+	if !hashed && e.kind != ExchangeTypeDirect && e.kind != ExchangeTypeTopic {
+		err = newConfigError(nil, "The %s partitioned queue can only be bound to a consistent hash, direct or topic exchange", queueName)
+		return
+	}
+
+	queue := &amqpPartitionedQueue{
+		name:   queueName,
+		hashed: hashed,
+	}
+	for i := 0; i < partitions; i++ {
+		routingKey := consistentHashWeight
+		if !hashed {
+			routingKey = queue.partitionName(i)
+		}
+
+		var q Queue
+		q, err = e.BindQueue(queue.partitionName(i), routingKey, conf...)
+		if err != nil {
+			err = newError(err, "Failed to declare the %d partition of the %s queue", i, queueName)
+			return
+		}
+
+		queue.partitions = append(queue.partitions, q)
+	}
+
+	pq = queue
+	return
+}
+
+// Consume subscribes a consumer in each partition assigned to the instance, to handle the messages with the handler function
+func (pq *amqpPartitionedQueue) Consume(handlerFn HandlerFunc, conf ...PartitionConsumeConfig) (err error) {
+	config := PartitionConsumeConfig{}
+	if len(conf) > 0 {
+		config = conf[0]
+	}
+
+	instances := config.Instances
+	if instances == 0 {
+		instances = 1
+	}
+
+	if instances < 0 || config.Instance < 0 || config.Instance >= instances {
+		err = newConfigError(nil, "Invalid instance %d of %d for the %s partitioned queue", config.Instance, instances, pq.name)
+		return
+	}
+
+	for i, q := range pq.partitions {
+		if i%instances != config.Instance {
+			continue
+		}
+
+		consumeConfig := config.ConsumeConfig
+		if consumeConfig.PrefetchCount == 0 {
+			consumeConfig.PrefetchCount = defaultPartitionPrefetchCount
+		}
+		if consumeConfig.ConsumerName != "" {
+			consumeConfig.ConsumerName = fmt.Sprintf("%s-%d", consumeConfig.ConsumerName, i)
+		}
+
+		err = q.Consume(handlerFn, consumeConfig)
+		if err != nil {
+			err = newError(err, "Failed to consume the %d partition of the %s queue", i, pq.name)
+			return
+		}
+	}
+
+	return
+}
+
+// RoutingKey returns the routing-key that must be used to publish the messages of an entity,
+// so every message of the same entity is routed to the same partition
+func (pq *amqpPartitionedQueue) RoutingKey(entityKey string) string {
+	if pq.hashed {
+		return entityKey
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(entityKey))
+
+	return pq.partitionName(int(h.Sum32() % uint32(len(pq.partitions))))
+}
+
+// Name returns the partitioned queue name
+func (pq *amqpPartitionedQueue) Name() string {
+	return pq.name
+}
+
+// Partitions returns the partition queues, ordered by their index
+func (pq *amqpPartitionedQueue) Partitions() []Queue {
+	return pq.partitions
+}
+
+// partitionName returns the queue name for a partition index
+func (pq *amqpPartitionedQueue) partitionName(i int) string {
+	return fmt.Sprintf("%s.%d", pq.name, i)
+}
@@ -0,0 +1,154 @@
+// Package prometheus exposes the go-amqp client metrics as Prometheus metrics
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	goamqp "github.com/delivery-much/go-amqp"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultNamespace is the namespace of the metrics when the configuration does not define one
+const defaultNamespace = "amqp"
+
+// Config represents the configuration used to create the Prometheus metrics
+type Config struct {
+	// Namespace is the prefix of the metric names.
+	//
+	// default: amqp
+	Namespace string
+
+	// Registerer is where the metrics are registered.
+	//
+	// default: prometheus.DefaultRegisterer
+	Registerer prometheus.Registerer
+
+	// Buckets are the histogram buckets of the publish and handler durations, in seconds.
+	//
+	// default: prometheus.DefBuckets
+	Buckets []float64
+}
+
+// metrics is a metrics collector that exposes the client metrics as Prometheus metrics
+type metrics struct {
+	published       *prometheus.CounterVec
+	publishDuration *prometheus.HistogramVec
+	returned        *prometheus.CounterVec
+	consumed        *prometheus.CounterVec
+	handlerDuration *prometheus.HistogramVec
+	inFlight        *prometheus.GaugeVec
+	connectionOpen  prometheus.Gauge
+}
+
+// NewMetrics creates a metrics collector that exposes the client metrics as Prometheus metrics,
+// registering them on the configured registerer.
+//
+// The collector is used by the client when defined in the Metrics field of the client Config.
+func NewMetrics(conf ...Config) (m goamqp.Metrics, err error) {
+	c := Config{}
+	if len(conf) > 0 {
+		c = conf[0]
+	}
+
+	if c.Namespace == "" {
+		c.Namespace = defaultNamespace
+	}
+	if c.Registerer == nil {
+		c.Registerer = prometheus.DefaultRegisterer
+	}
+	if len(c.Buckets) == 0 {
+		c.Buckets = prometheus.DefBuckets
+	}
+
+	pm := &metrics{
+		published: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: c.Namespace,
+			Name:      "messages_published_total",
+			Help:      "Number of messages published per exchange and outcome (published, confirmed, nacked or failed).",
+		}, []string{"exchange", "outcome"}),
+		publishDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: c.Namespace,
+			Name:      "publish_duration_seconds",
+			Help:      "Duration of the message publishing per exchange, including the wait for the server confirmation.",
+			Buckets:   c.Buckets,
+		}, []string{"exchange"}),
+		returned: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: c.Namespace,
+			Name:      "messages_returned_total",
+			Help:      "Number of published messages returned by the server per exchange.",
+		}, []string{"exchange"}),
+		consumed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: c.Namespace,
+			Name:      "deliveries_consumed_total",
+			Help:      "Number of messages consumed per queue and outcome (ack, nack or reject).",
+		}, []string{"queue", "outcome"}),
+		handlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: c.Namespace,
+			Name:      "handler_duration_seconds",
+			Help:      "Duration of the message handling per queue.",
+			Buckets:   c.Buckets,
+		}, []string{"queue"}),
+		inFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: c.Namespace,
+			Name:      "handlers_in_flight",
+			Help:      "Number of messages being handled per queue.",
+		}, []string{"queue"}),
+		connectionOpen: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: c.Namespace,
+			Name:      "connection_open",
+			Help:      "Whether the client connection is open (1) or closed (0).",
+		}),
+	}
+
+	collectors := []prometheus.Collector{
+		pm.published,
+		pm.publishDuration,
+		pm.returned,
+		pm.consumed,
+		pm.handlerDuration,
+		pm.inFlight,
+		pm.connectionOpen,
+	}
+	for _, collector := range collectors {
+		err = c.Registerer.Register(collector)
+		if err != nil {
+			err = fmt.Errorf("Failed to register the Prometheus metrics, %w", err)
+			return
+		}
+	}
+
+	m = pm
+	return
+}
+
+func (m *metrics) MessagePublished(exchange string, outcome goamqp.PublishOutcome, duration time.Duration) {
+	m.published.WithLabelValues(exchange, outcome.ToString()).Inc()
+	m.publishDuration.WithLabelValues(exchange).Observe(duration.Seconds())
+}
+
+func (m *metrics) MessageReturned(exchange string) {
+	m.returned.WithLabelValues(exchange).Inc()
+}
+
+func (m *metrics) HandlerStarted(queue string) {
+	m.inFlight.WithLabelValues(queue).Inc()
+}
+
+func (m *metrics) HandlerFinished(queue string, duration time.Duration) {
+	m.inFlight.WithLabelValues(queue).Dec()
+	m.handlerDuration.WithLabelValues(queue).Observe(duration.Seconds())
+}
+
+func (m *metrics) DeliveryConsumed(queue string, outcome goamqp.DeliveryOutcome) {
+	m.consumed.WithLabelValues(queue, outcome.ToString()).Inc()
+}
+
+func (m *metrics) ConnectionStateChanged(open bool) {
+	if open {
+		m.connectionOpen.Set(1)
+		return
+	}
+
+	m.connectionOpen.Set(0)
+}
@@ -31,6 +31,12 @@ type PublishConfig struct {
 	// default: false
 	WaitConfirmation bool
 
+	// Delay defines for how long the message must be held before it is routed to the queues.
+	// How the message is delayed depends on the DelayStrategy of the publisher.
+	//
+	// default: 0 (the message is not delayed)
+	Delay time.Duration
+
 	// Message specific fields
 
 	// Application or exchange specific fields,
@@ -54,6 +60,7 @@ type PublishConfig struct {
 // getPublishingFromConfig returns a new amqp.Publishing struct with no body, using the PublishConfig values
 func (c PublishConfig) getPublishingFromConfig() amqp.Publishing {
 	return amqp.Publishing{
+		Headers:         c.Headers.toAmqpTable(),
 		ContentType:     c.ContentType,
 		ContentEncoding: c.ContentEncoding,
 		DeliveryMode:    c.DeliveryMode,
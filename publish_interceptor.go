@@ -0,0 +1,51 @@
+package amqp
+
+import (
+	"context"
+)
+
+// PublishMessage represents a message that is about to be published
+type PublishMessage struct {
+	// Exchange is the name of the exchange where the message is published
+	Exchange string
+	// RoutingKey is the routing-key used to route the message
+	RoutingKey string
+	// Body is the message payload
+	Body []byte
+	// Config is the message configuration, with the publisher defaults already applied
+	Config PublishConfig
+}
+
+// SetHeader sets a message header.
+//
+// The headers table is copied before it is changed, so the table provided by the publish caller is not modified.
+func (m *PublishMessage) SetHeader(key string, value any) {
+	headers := Table{}
+	for k, v := range m.Config.Headers {
+		headers[k] = v
+	}
+	headers[key] = value
+
+	m.Config.Headers = headers
+}
+
+// PublishFunc represents a function that publishes a message.
+//
+// It returns an error if the message could not be published, or if it was not confirmed by the server when the publishing waits for a confirmation.
+type PublishFunc func(context.Context, PublishMessage) error
+
+// PublishInterceptor represents a function that wraps a PublishFunc, returning a new PublishFunc.
+//
+// The interceptor can change the message before calling the wrapped function (i.e. to add headers, sign, encrypt or compress the message),
+// veto the publishing by returning an error without calling it, and observe the publishing result, including the server confirmation.
+type PublishInterceptor func(PublishFunc) PublishFunc
+
+// chainPublishInterceptors wraps the publish function with the interceptors,
+// so the first interceptor is the outermost one, and is the first to run
+func chainPublishInterceptors(publishFn PublishFunc, interceptors ...PublishInterceptor) PublishFunc {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		publishFn = interceptors[i](publishFn)
+	}
+
+	return publishFn
+}
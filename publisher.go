@@ -3,8 +3,8 @@ package amqp
 import (
 	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
+	"sync"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -18,14 +18,33 @@ type amqpPublisher struct {
 	// channel its the channel that the publisher is on
 	channel *amqp.Channel
 
+	// queueName represents the name of the queue that the publisher publishes messages to, using the default exchange.
+	// When it is empty, the messages are published on the exchange using the provided routing-key.
+	queueName string
+
 	// waitConfirmation defines if the publisher is configured to wait for the server confirmation when publishing messages
 	waitConfirmation bool
+
+	// config its the configuration used to create the publisher, which defines the publisher defaults
+	config PublisherConfig
+
+	// client its the client that created the publisher
+	client *client
+
+	// interceptors are the interceptors that wrap the message publishing
+	interceptors []PublishInterceptor
+
+	// delayLadders are the delay ladders declared by the publisher
+	delayLadders   map[string]bool
+	delayLaddersMu sync.Mutex
 }
 
-func newPublisher(exchangeName string, ch *amqp.Channel) *amqpPublisher {
+func newPublisher(c *client, exchangeName string, ch *amqp.Channel) *amqpPublisher {
 	return &amqpPublisher{
+		client:       c,
 		exchangeName: exchangeName,
 		channel:      ch,
+		delayLadders: map[string]bool{},
 		connectedStruct: connectedStruct{
 			ch: ch,
 		},
@@ -33,72 +52,115 @@ func newPublisher(exchangeName string, ch *amqp.Channel) *amqpPublisher {
 }
 
 // Publish publishes a message on a exchange
-func (p *amqpPublisher) Publish(body []byte, key string, conf ...PublishConfig) (err error) {
+func (p *amqpPublisher) Publish(body []byte, key string, conf ...PublishConfig) error {
+	return p.PublishWithContext(context.TODO(), body, key, conf...)
+}
+
+// PublishWithContext publishes a message on a exchange, passing the context through the publish interceptors
+func (p *amqpPublisher) PublishWithContext(ctx context.Context, body []byte, key string, conf ...PublishConfig) error {
 	c := PublishConfig{}
 	if len(conf) > 0 {
 		c = conf[0]
 	}
 
-	publishing := c.getPublishingFromConfig()
-	publishing.Body = body
-
-	err = p.channel.PublishWithContext(
-		context.TODO(),
-		p.exchangeName,
-		key,
-		c.Mandatory,
-		c.Imediate,
-		publishing,
-	)
-	if err != nil {
-		err = fmt.Errorf("Failed to publish message, %v", err)
-		return
+	if p.queueName != "" {
+		key = p.queueName
 	}
 
-	if p.waitConfirmation && c.WaitConfirmation {
-		confirmation := <-p.channel.NotifyPublish(make(chan amqp.Confirmation, 1))
-		if !confirmation.Ack {
-			err = errors.New("The server did not acknowledge the message publishing")
+	interceptors := []PublishInterceptor{}
+	if p.client != nil {
+		if p.client.tracer != nil {
+			interceptors = append(interceptors, p.client.tracer.interceptor())
 		}
+		interceptors = append(interceptors, p.client.interceptors...)
 	}
-
-	return
+	interceptors = append(interceptors, p.interceptors...)
+
+	return chainPublishInterceptors(p.publish, interceptors...)(ctx, PublishMessage{
+		Exchange:   p.exchangeName,
+		RoutingKey: key,
+		Body:       body,
+		Config:     p.config.applyDefaults(c),
+	})
 }
 
 // PublishJSON publishes a json encoded struct on a exchange
-func (p *amqpPublisher) PublishJSON(v any, key string, conf ...PublishConfig) (err error) {
-	c := PublishConfig{}
-	if len(conf) > 0 {
-		c = conf[0]
-	}
+func (p *amqpPublisher) PublishJSON(v any, key string, conf ...PublishConfig) error {
+	return p.PublishJSONWithContext(context.TODO(), v, key, conf...)
+}
 
+// PublishJSONWithContext publishes a json encoded struct on a exchange, passing the context through the publish interceptors
+func (p *amqpPublisher) PublishJSONWithContext(ctx context.Context, v any, key string, conf ...PublishConfig) error {
 	body, err := json.Marshal(v)
 	if err != nil {
-		return fmt.Errorf("Failed to encode payload to a JSON, %v", err)
+		return newError(err, "Failed to encode payload to a JSON")
+	}
+
+	return p.PublishWithContext(ctx, body, key, conf...)
+}
+
+// Intercept adds interceptors that will wrap the message publishing of the publisher
+func (p *amqpPublisher) Intercept(interceptors ...PublishInterceptor) {
+	p.interceptors = append(p.interceptors, interceptors...)
+}
+
+// publish publishes the message on the publisher channel, checking if the server blocked the connection, delaying it if needed,
+// and waits for the server confirmation when the publishing requires it
+func (p *amqpPublisher) publish(ctx context.Context, msg PublishMessage) (err error) {
+	start := time.Now()
+	outcome := PublishOutcomeFailed
+	defer func() {
+		p.client.metrics().MessagePublished(msg.Exchange, outcome, time.Since(start))
+	}()
+
+	err = p.client.checkBlocked(ctx, p.config.WaitUnblocked)
+	if err != nil {
+		return
 	}
 
+	c := msg.Config
 	publishing := c.getPublishingFromConfig()
-	publishing.Body = body
+	publishing.Body = msg.Body
+
+	exchange, key := msg.Exchange, msg.RoutingKey
+	if c.Delay > 0 {
+		exchange, key, err = p.delay(&publishing, exchange, key, c.Delay)
+		if err != nil {
+			err = newError(err, "Failed to delay message")
+			return
+		}
+	}
 
-	err = p.channel.PublishWithContext(
-		context.TODO(),
-		p.exchangeName,
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		exchange,
 		key,
 		c.Mandatory,
 		c.Imediate,
 		publishing,
 	)
 	if err != nil {
-		err = fmt.Errorf("Failed to publish message, %v", err)
+		err = newError(err, "Failed to publish message")
 		return
 	}
 
-	if p.waitConfirmation && c.WaitConfirmation {
-		confirmation := <-p.channel.NotifyPublish(make(chan amqp.Confirmation, 1))
-		if !confirmation.Ack {
-			err = errors.New("The server did not acknowledge the message publishing")
-		}
+	if !p.waitConfirmation || !c.WaitConfirmation || confirmation == nil {
+		outcome = PublishOutcomePublished
+		return
+	}
+
+	acked, waitErr := confirmation.WaitContext(ctx)
+	if waitErr != nil {
+		err = newError(waitErr, "Failed to wait for the message publishing confirmation")
+		return
+	}
+
+	if !acked {
+		outcome = PublishOutcomeNacked
+		err = ErrNotAcknowledged
+		return
 	}
 
+	outcome = PublishOutcomeConfirmed
 	return
 }
@@ -0,0 +1,107 @@
+package amqp
+
+import (
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// PublisherConfig represents the configuration that can be provided when creating a publisher
+type PublisherConfig struct {
+	// When NoWait is set to true, the publisher will not be created in confirmation mode.
+	// This means that when a message is published using this publisher, the library will not wait for confirmation a from the server.
+	//
+	// default: false
+	NoWait bool
+
+	// DelayStrategy defines how the publisher delays the messages published with a Delay.
+	//
+	// default: DelayStrategyPlugin
+	DelayStrategy DelayStrategy
+
+	// DelayPrecision is the precision of the delays when using the DelayStrategyQueues strategy.
+	// The delays are rounded to the nearest multiple of the precision, and the delay ladder level n holds the messages for 2^n times the precision.
+	//
+	// default: 1 second
+	DelayPrecision time.Duration
+
+	// DelayLevels is the number of levels of the delay ladder when using the DelayStrategyQueues strategy, up to 32.
+	// The maximum delay is (2^DelayLevels - 1) times the DelayPrecision.
+	//
+	// default: 28 (about 8.5 years, with the default precision)
+	DelayLevels int
+
+	// When WaitUnblocked is set to true, publishing a message while the server blocks the connection
+	// waits until the connection is unblocked, or until the publishing context is done.
+	// Otherwise, the publishing fails fast with an error matching ErrConnectionBlocked.
+	//
+	// Use PublishWithContext with a context deadline to limit how long the publishing waits.
+	//
+	// default: false
+	WaitUnblocked bool
+
+	// Publisher defaults, used when the PublishConfig of a message does not define its own values
+
+	// MessageIdGenerator generates the id of the messages published without a MessageId.
+	// The library provides the NewUUIDv4 and NewULID generators, but any IDGenerator can be used.
+	//
+	// default: nil (the messages are published without an id)
+	MessageIdGenerator IDGenerator
+
+	// When AutoTimestamp is set to true, the messages published without a Timestamp are stamped with the current time.
+	//
+	// default: false
+	AutoTimestamp bool
+
+	// AppId is the id of the application that publishes the messages.
+	//
+	// default: ""
+	AppId string
+
+	// ContentType is the MIME content type of the messages (i.e. "application/json").
+	//
+	// default: ""
+	ContentType string
+
+	// When Persistent is set to true, the messages are published with the persistent delivery mode,
+	// so they are stored on disk by the server when routed to durable queues.
+	//
+	// default: false (the messages are transient)
+	Persistent bool
+}
+
+// delayLadder returns the precision and number of levels of the delay ladder
+func (c PublisherConfig) delayLadder() (precision time.Duration, levels int) {
+	precision = c.DelayPrecision
+	if precision <= 0 {
+		precision = defaultDelayPrecision
+	}
+
+	levels = c.DelayLevels
+	if levels <= 0 {
+		levels = defaultDelayLevels
+	}
+
+	return
+}
+
+// applyDefaults fills the publish config fields that were not defined with the publisher defaults
+func (c PublisherConfig) applyDefaults(pc PublishConfig) PublishConfig {
+	if pc.MessageId == "" && c.MessageIdGenerator != nil {
+		pc.MessageId = c.MessageIdGenerator()
+	}
+	if pc.Timestamp.IsZero() && c.AutoTimestamp {
+		pc.Timestamp = time.Now()
+	}
+	if pc.AppId == "" {
+		pc.AppId = c.AppId
+	}
+	if pc.ContentType == "" {
+		pc.ContentType = c.ContentType
+	}
+	if pc.DeliveryMode == 0 && c.Persistent {
+		pc.DeliveryMode = amqp.Persistent
+	}
+
+	return pc
+}
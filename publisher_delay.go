@@ -0,0 +1,210 @@
+package amqp
+
+import (
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// delayHeader is the message header used by the delayed message exchange plugin to delay a message
+const delayHeader = "x-delay"
+
+// delayLevelHeader is the message header that defines if a message waits on a level of the delay ladder.
+// It does not start with "x-", since the headers exchanges ignore those headers when matching all the binding headers.
+const delayLevelHeader = "amqp-delay-level-%d"
+
+const (
+	// defaultDelayPrecision is the delay precision used when the publisher does not define one
+	defaultDelayPrecision = time.Second
+	// defaultDelayLevels is the number of levels of the delay ladder used when the publisher does not define one
+	defaultDelayLevels = 28
+	// maxDelayLevels is the maximum number of levels of the delay ladder
+	maxDelayLevels = 32
+)
+
+// delay prepares the publishing to be delayed according to the publisher delay strategy,
+// and returns the exchange and routing-key where the publishing must be published
+func (p *amqpPublisher) delay(publishing *amqp.Publishing, exchange, key string, delay time.Duration) (string, string, error) {
+	switch p.config.DelayStrategy {
+	case "", DelayStrategyPlugin:
+		headers := amqp.Table{}
+		for k, v := range publishing.Headers {
+			headers[k] = v
+		}
+		headers[delayHeader] = delay.Milliseconds()
+		publishing.Headers = headers
+
+		return exchange, key, nil
+	case DelayStrategyQueues:
+		return p.delayOnLadder(publishing, exchange, key, delay)
+	default:
+		return "", "", newConfigError(nil, "Invalid delay strategy %q", p.config.DelayStrategy)
+	}
+}
+
+// delayOnLadder prepares the publishing to go through the delay ladder of the exchange, or of the queue when publishing on the default exchange.
+//
+// The delay is rounded to the nearest multiple of the publisher delay precision, and split in its binary levels:
+// the ladder level n holds the messages for 2^n times the precision, so the message waits only on the levels of the bits set in the rounded delay.
+// The publishing headers define on which levels the message waits, and the routing-key is kept, so the message is routed as usual after the delay.
+func (p *amqpPublisher) delayOnLadder(publishing *amqp.Publishing, exchange, key string, delay time.Duration) (string, string, error) {
+	precision, levels := p.config.delayLadder()
+	if levels > maxDelayLevels {
+		return "", "", newConfigError(nil, "The delay ladder can not have more than %d levels", maxDelayLevels)
+	}
+
+	units := delayUnits(delay, precision)
+	if units == 0 {
+		return exchange, key, nil
+	}
+	if units >= 1<<levels {
+		return "", "", newConfigError(nil, "The %s delay exceeds the %s maximum delay of the delay ladder", delay, time.Duration(1<<levels-1)*precision)
+	}
+
+	ladder := delayLadderName(exchange, key, precision)
+	err := p.declareDelayLadder(ladder, exchange, key, precision, levels)
+	if err != nil {
+		return "", "", err
+	}
+
+	headers := amqp.Table{}
+	for k, v := range publishing.Headers {
+		headers[k] = v
+	}
+
+	waits, top := delayLevelWaits(units, levels)
+	for level, wait := range waits {
+		headers[fmt.Sprintf(delayLevelHeader, level)] = wait
+	}
+	publishing.Headers = headers
+
+	// the levels above the highest bit set would only pass the message on, so it enters the ladder on the highest bit set
+	return delayLevelName(ladder, top), key, nil
+}
+
+// delayUnits returns the delay rounded to the nearest multiple of the precision, in precision units
+func delayUnits(delay, precision time.Duration) uint64 {
+	if delay <= 0 {
+		return 0
+	}
+
+	return uint64((delay + precision/2) / precision)
+}
+
+// delayLevelWaits returns, for each level of the delay ladder, if a message delayed for the units waits on the level ("1") or passes it on ("0"),
+// and the highest level where the message waits
+func delayLevelWaits(units uint64, levels int) (waits []string, top int) {
+	waits = make([]string, levels)
+	for level := range waits {
+		waits[level] = "0"
+		if units&(1<<level) != 0 {
+			waits[level] = "1"
+			top = level
+		}
+	}
+
+	return
+}
+
+// delayLadderName returns the name of the delay ladder of the exchange, or of the queue when publishing on the default exchange.
+// The name includes the target kind and the precision, since the levels of ladders with different targets or precisions are declared with different arguments.
+func delayLadderName(exchange, key string, precision time.Duration) string {
+	if exchange == defaultExchangeName {
+		return fmt.Sprintf("%s.delay.queue.%s", key, precision)
+	}
+
+	return fmt.Sprintf("%s.delay.exchange.%s", exchange, precision)
+}
+
+// delayLevelName returns the name of the exchange and queue of a delay ladder level
+func delayLevelName(ladder string, level int) string {
+	return fmt.Sprintf("%s.%d", ladder, level)
+}
+
+// declareDelayLadder declares the delay ladder of the exchange, or of the queue when publishing on the default exchange, if the publisher did not declare it yet.
+//
+// Each level n has a headers exchange and a queue with a message TTL of 2^n times the precision:
+// the messages that wait on the level are routed to the level queue, and dead-lettered to the level below when they expire,
+// and the other messages are routed directly to the level below.
+// Below the level 0 is the publisher exchange, or the publisher queue, where the messages are routed with their original routing-key.
+//
+// The ladder is declared on a short-lived channel, so the server closing the channel on a declare error does not close the publisher channel.
+func (p *amqpPublisher) declareDelayLadder(ladder, exchange, key string, precision time.Duration, levels int) (err error) {
+	p.delayLaddersMu.Lock()
+	defer p.delayLaddersMu.Unlock()
+
+	if p.delayLadders[ladder] {
+		return
+	}
+
+	err = p.client.withAdminChannel(func(ch *amqp.Channel) (err error) {
+		for level := 0; level < levels; level++ {
+			err = declareDelayLevel(ch, ladder, exchange, key, precision, level)
+			if err != nil {
+				return
+			}
+		}
+
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	p.delayLadders[ladder] = true
+	return
+}
+
+// declareDelayLevel declares the exchange and queue of a delay ladder level, and binds them to the level below
+func declareDelayLevel(ch *amqp.Channel, ladder, exchange, key string, precision time.Duration, level int) (err error) {
+	name := delayLevelName(ladder, level)
+	header := fmt.Sprintf(delayLevelHeader, level)
+
+	below := exchange
+	if level > 0 {
+		below = delayLevelName(ladder, level-1)
+	}
+
+	err = ch.ExchangeDeclare(name, ExchangeTypeHeaders.ToString(), true, false, false, false, nil)
+	if err != nil {
+		err = newError(err, "Failed to declare the %s delay exchange", name)
+		return
+	}
+
+	_, err = ch.QueueDeclare(
+		name,
+		true,
+		false,
+		false,
+		false,
+		amqp.Table{
+			"x-message-ttl":          (time.Duration(1<<level) * precision).Milliseconds(),
+			"x-dead-letter-exchange": below,
+		},
+	)
+	if err != nil {
+		err = newError(err, "Failed to declare the %s delay queue", name)
+		return
+	}
+
+	err = ch.QueueBind(name, "", name, false, amqp.Table{"x-match": "all", header: "1"})
+	if err != nil {
+		err = newError(err, "Failed to bind the %s delay queue", name)
+		return
+	}
+
+	passArgs := amqp.Table{"x-match": "all", header: "0"}
+	switch {
+	case level > 0 || exchange != defaultExchangeName:
+		err = ch.ExchangeBind(below, "", name, false, passArgs)
+	default:
+		// the default exchange can not be bound, so the level 0 routes the messages directly to the publisher queue
+		err = ch.QueueBind(key, "", name, false, passArgs)
+	}
+	if err != nil {
+		err = newError(err, "Failed to bind the %s delay exchange to the level below", name)
+	}
+
+	return
+}
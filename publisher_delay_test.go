@@ -0,0 +1,145 @@
+package amqp
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestDelayUnits(t *testing.T) {
+	tests := []struct {
+		name      string
+		delay     time.Duration
+		precision time.Duration
+		want      uint64
+	}{
+		{"negative delay", -time.Second, time.Second, 0},
+		{"zero delay", 0, time.Second, 0},
+		{"below half the precision rounds to zero", 499 * time.Millisecond, time.Second, 0},
+		{"half the precision rounds up", 500 * time.Millisecond, time.Second, 1},
+		{"exact multiple", 3 * time.Second, time.Second, 3},
+		{"rounds down", 3*time.Second + 400*time.Millisecond, time.Second, 3},
+		{"rounds up", 3*time.Second + 600*time.Millisecond, time.Second, 4},
+		{"millisecond precision", 1500 * time.Millisecond, time.Millisecond, 1500},
+		{"minute precision", 90 * time.Minute, time.Minute, 90},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := delayUnits(tt.delay, tt.precision)
+			if got != tt.want {
+				t.Errorf("delayUnits(%s, %s) = %d, want %d", tt.delay, tt.precision, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDelayLevelWaits(t *testing.T) {
+	tests := []struct {
+		name      string
+		units     uint64
+		levels    int
+		wantWaits []string
+		wantTop   int
+	}{
+		{"one unit waits on the level 0", 1, 4, []string{"1", "0", "0", "0"}, 0},
+		{"a power of two waits on a single level", 4, 4, []string{"0", "0", "1", "0"}, 2},
+		{"waits on every bit set", 5, 4, []string{"1", "0", "1", "0"}, 2},
+		{"the maximum delay waits on every level", 15, 4, []string{"1", "1", "1", "1"}, 3},
+		{"the top level is the highest bit set", 10, 4, []string{"0", "1", "0", "1"}, 3},
+		{"the 32 levels ladder", 1<<32 - 1, 32, []string{
+			"1", "1", "1", "1", "1", "1", "1", "1",
+			"1", "1", "1", "1", "1", "1", "1", "1",
+			"1", "1", "1", "1", "1", "1", "1", "1",
+			"1", "1", "1", "1", "1", "1", "1", "1",
+		}, 31},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			waits, top := delayLevelWaits(tt.units, tt.levels)
+			if !reflect.DeepEqual(waits, tt.wantWaits) {
+				t.Errorf("delayLevelWaits(%d, %d) waits = %v, want %v", tt.units, tt.levels, waits, tt.wantWaits)
+			}
+
+			if top != tt.wantTop {
+				t.Errorf("delayLevelWaits(%d, %d) top = %d, want %d", tt.units, tt.levels, top, tt.wantTop)
+			}
+		})
+	}
+}
+
+func TestDelayLadderName(t *testing.T) {
+	tests := []struct {
+		name      string
+		exchange  string
+		key       string
+		precision time.Duration
+		want      string
+	}{
+		{"exchange publisher", "orders", "order.created", time.Second, "orders.delay.exchange.1s"},
+		{"queue publisher", defaultExchangeName, "orders", time.Second, "orders.delay.queue.1s"},
+		{"different precision", "orders", "order.created", 100 * time.Millisecond, "orders.delay.exchange.100ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := delayLadderName(tt.exchange, tt.key, tt.precision)
+			if got != tt.want {
+				t.Errorf("delayLadderName(%q, %q, %s) = %q, want %q", tt.exchange, tt.key, tt.precision, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDelayOnLadderLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  PublisherConfig
+		delay   time.Duration
+		wantErr bool
+	}{
+		{
+			name:   "a delay that rounds to zero is not delayed",
+			config: PublisherConfig{DelayStrategy: DelayStrategyQueues},
+			delay:  100 * time.Millisecond,
+		},
+		{
+			name:    "a delay above the maximum delay",
+			config:  PublisherConfig{DelayStrategy: DelayStrategyQueues, DelayLevels: 4},
+			delay:   16 * time.Second,
+			wantErr: true,
+		},
+		{
+			name:    "more levels than the maximum",
+			config:  PublisherConfig{DelayStrategy: DelayStrategyQueues, DelayLevels: maxDelayLevels + 1},
+			delay:   time.Second,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPublisher(nil, "orders", nil)
+			p.config = tt.config
+
+			exchange, key, err := p.delayOnLadder(&amqp.Publishing{}, "orders", "order.created", tt.delay)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("delayOnLadder() = %q, %q, want an error", exchange, key)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("delayOnLadder() returned an unexpected error: %v", err)
+			}
+
+			if exchange != "orders" || key != "order.created" {
+				t.Errorf("delayOnLadder() = %q, %q, want the publishing not delayed", exchange, key)
+			}
+		})
+	}
+}
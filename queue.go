@@ -1,7 +1,9 @@
 package amqp
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -12,16 +14,34 @@ type amqpQueueBind struct {
 	routingKey string
 
 	// exchange its the exchange that the queue is on
-	exchange Exchange
+	exchange *amqpExchange
 
 	// exchangeChannel its the amqp channel that the Queue is on
 	channel *amqp.Channel
 
+	// queueType its the queue type defined when the queue was declared, if any
+	queueType QueueType
+
+	// config its the configuration used to declare the queue, or nil if the queue was not declared by the library
+	config *QueueBindConfig
+
+	// bound defines if the queue was bound to the exchange using its routing-key
+	bound bool
+
+	// deadLetters its the dead-letter queue declared along with the queue, if any
+	deadLetters Queue
+
 	// preHandleFuncs are the functions that will be called before the message handling
 	preHandleFuncs []PreHandleFunc
 
 	// postHandleFuncs are the functions that will be called after the message handling
 	postHandleFuncs []PostHandleFunc
+
+	// middlewares are the middlewares that wrap the message handling
+	middlewares []Middleware
+
+	// errorHandlers are the handlers for the errors returned in the handle responses
+	errorHandlers []ErrorHandler
 }
 
 // Consume subscribes a consumer in the routing key to handle the messages with the handler function
@@ -38,6 +58,39 @@ func (q *amqpQueueBind) Consume(handlerFn HandlerFunc, conf ...ConsumeConfig) (e
 		consumerName = fmt.Sprintf("%s-%s-%s-consumer", exchangeName, queueName, q.routingKey)
 	}
 
+	c := &consumer{
+		name:      consumerName,
+		queue:     q,
+		handlerFn: handlerFn,
+		config:    config,
+	}
+
+	args := config.Args
+	if c.isStream() {
+		args, err = c.prepareStream()
+		if err != nil {
+			err = newError(err, "Failed to consume the %s stream", queueName)
+			return
+		}
+	}
+
+	args, err = c.consumeArgs(args)
+	if err != nil {
+		err = newError(err, "Failed to consume queue")
+		return
+	}
+
+	if c.config.PrefetchCount > 0 {
+		err = q.channel.Qos(c.config.PrefetchCount, 0, false)
+		if err != nil {
+			err = newError(err, "Failed to set the consumer prefetch count")
+			return
+		}
+	}
+
+	c.cancelled = q.exchange.cancels.watch(q.channel, consumerName)
+	c.closed = q.channel.NotifyClose(make(chan *amqp.Error, 1))
+
 	msgs, err := q.channel.Consume(
 		queueName,
 		consumerName,
@@ -45,17 +98,133 @@ func (q *amqpQueueBind) Consume(handlerFn HandlerFunc, conf ...ConsumeConfig) (e
 		config.Exclusive,
 		config.NoLocal,
 		config.NoWait,
-		config.Args.toAmqpTable(),
+		args.toAmqpTable(),
 	)
 	if err != nil {
-		err = fmt.Errorf("Failed to consume queue, %v", err)
+		q.exchange.cancels.unwatch(consumerName)
+		err = newError(err, "Failed to consume queue")
 		return
 	}
+	// the consumer is running before it is reported by the client health, so a readiness check does not see it stopped
+	c.running.Store(true)
+	if cl := q.exchange.client; cl != nil {
+		cl.health.addConsumer(c)
+	}
+
+	go c.run(msgs)
+	return
+}
+
+// redeclare declares the queue again using its original configuration, and binds it to the exchange if it was bound.
+//
+// If the queue was not declared by the library, it only checks if the queue exists on the server.
+// The queue is declared on a short-lived channel, so the server closing the channel on an error (i.e. when the queue is not found)
+// does not close the queue channel, nor the exchange channel shared by the other queues of the exchange.
+func (q *amqpQueueBind) redeclare() (err error) {
+	e := q.exchange
+	err = e.client.withAdminChannel(func(ch *amqp.Channel) (err error) {
+		if q.config == nil {
+			_, err = ch.QueueDeclarePassive(q.name, false, false, false, false, nil)
+			return
+		}
+
+		admin := newExchange(e.client, e.name, e.kind, ch)
+		_, err = admin.declareQueue(q.name, q.routingKey, *q.config)
+		if err != nil || !q.bound {
+			return
+		}
+
+		err = ch.QueueBind(
+			q.name,
+			q.routingKey,
+			e.name,
+			q.config.NoWait,
+			q.config.bindArgs().toAmqpTable(),
+		)
+		return
+	})
+	if err != nil {
+		err = newError(err, "Failed to declare the %s queue again", q.name)
+	}
 
-	go consumeLoop(msgs, q, handlerFn)
 	return
 }
 
+// Inspect returns the current state of the queue, checking if the queue exists on the server
+func (q *amqpQueueBind) Inspect() (info QueueInfo, err error) {
+	err = q.exchange.client.withAdminChannel(func(ch *amqp.Channel) (err error) {
+		declared, err := ch.QueueDeclarePassive(q.name, false, false, false, false, nil)
+		if err != nil {
+			return
+		}
+
+		info = QueueInfo{
+			Name:      declared.Name,
+			Messages:  declared.Messages,
+			Consumers: declared.Consumers,
+		}
+		return
+	})
+	if err != nil {
+		err = newError(err, "Failed to inspect the %s queue", q.name)
+	}
+
+	return
+}
+
+// Purge removes all the ready messages from the queue, and returns how many messages were removed
+func (q *amqpQueueBind) Purge() (count int, err error) {
+	err = q.exchange.client.withAdminChannel(func(ch *amqp.Channel) (err error) {
+		count, err = ch.QueuePurge(q.name, false)
+		return
+	})
+	if err != nil {
+		err = newError(err, "Failed to purge the %s queue", q.name)
+	}
+
+	return
+}
+
+// Delete deletes the queue from the server, and returns how many messages were removed along with it
+func (q *amqpQueueBind) Delete(ifUnused, ifEmpty bool) (count int, err error) {
+	err = q.exchange.client.withAdminChannel(func(ch *amqp.Channel) (err error) {
+		count, err = ch.QueueDelete(q.name, ifUnused, ifEmpty, false)
+		return
+	})
+	if err != nil {
+		err = newError(err, "Failed to delete the %s queue", q.name)
+	}
+
+	return
+}
+
+// WaitUntilEmpty inspects the queue on every interval until it has no ready messages, or until the context is done
+func (q *amqpQueueBind) WaitUntilEmpty(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return newConfigError(nil, "The %s queue wait interval must be positive", q.name)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		info, err := q.Inspect()
+		if err != nil {
+			return err
+		}
+
+		if info.Messages == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return newError(ctx.Err(), "The %s queue still has %d messages", q.name, info.Messages)
+		case <-ticker.C:
+		}
+	}
+}
+
 // Before adds functions that will be called in the queue before the message handling
 func (q *amqpQueueBind) Before(funcs ...PreHandleFunc) {
 	q.preHandleFuncs = append(q.preHandleFuncs, funcs...)
@@ -81,6 +250,11 @@ func (q *amqpQueueBind) RoutingKey() string {
 	return q.routingKey
 }
 
+// DeadLetters returns the dead-letter queue declared along with the queue, or nil if it has no dead-letter topology
+func (q *amqpQueueBind) DeadLetters() Queue {
+	return q.deadLetters
+}
+
 // PreHandleFuncs returns the pre handle funcs for the queue
 func (q *amqpQueueBind) PreHandleFuncs() []PreHandleFunc {
 	return q.preHandleFuncs
@@ -90,3 +264,18 @@ func (q *amqpQueueBind) PreHandleFuncs() []PreHandleFunc {
 func (e *amqpQueueBind) PostHandleFuncs() []PostHandleFunc {
 	return e.postHandleFuncs
 }
+
+// OnError adds handlers for the errors returned in the handle responses of the queue
+func (q *amqpQueueBind) OnError(handlers ...ErrorHandler) {
+	q.errorHandlers = append(q.errorHandlers, handlers...)
+}
+
+// Middlewares returns the middlewares for the queue
+func (q *amqpQueueBind) Middlewares() []Middleware {
+	return q.middlewares
+}
+
+// Use adds middlewares that will wrap the message handling of the queue
+func (q *amqpQueueBind) Use(middlewares ...Middleware) {
+	q.middlewares = append(q.middlewares, middlewares...)
+}
@@ -1,5 +1,11 @@
 package amqp
 
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
 // QueueConfig represents the configuration for binding a queue to an exchange
 type QueueBindConfig struct {
 	// When a queue is declared as durable,
@@ -38,8 +44,257 @@ type QueueBindConfig struct {
 	// default: false
 	NoWait bool
 
+	// Type defines the queue type, which can be classic, quorum or stream.
+	// When empty, the server uses its default queue type (usually classic).
+	//
+	// Quorum queues and streams must be durable, and can not be exclusive or auto-delete.
+	//
+	// default: ""
+	Type QueueType
+
+	// MessageTTL defines for how long a message can stay in the queue before it expires.
+	// Expired messages are discarded or, if the queue has a dead-letter exchange, dead-lettered.
+	// It is not supported by streams.
+	//
+	// default: 0 (messages do not expire)
+	MessageTTL time.Duration
+
+	// Expires defines for how long the queue can be unused (no consumers and no redeclarations) before it is deleted.
+	// It is not supported by streams.
+	//
+	// default: 0 (the queue does not expire)
+	Expires time.Duration
+
+	// MaxLength defines the maximum number of ready messages the queue can hold.
+	// When the limit is reached, the Overflow behaviour is applied.
+	// It is not supported by streams.
+	//
+	// default: 0 (no limit)
+	MaxLength int
+
+	// MaxLengthBytes defines the maximum size, in bytes, of the ready messages bodies the queue can hold.
+	// When the limit is reached, the Overflow behaviour is applied.
+	// For streams, it defines the maximum size of the stream on disk.
+	//
+	// default: 0 (no limit)
+	MaxLengthBytes int64
+
+	// Overflow defines the queue behaviour when the MaxLength or MaxLengthBytes limits are reached.
+	// It is not supported by streams.
+	//
+	// default: "" (the server uses QueueOverflowDropHead)
+	Overflow QueueOverflow
+
+	// DeadLetterExchange defines the exchange where the messages are republished when they are rejected,
+	// nacked without requeue, expired or dropped due to the queue length limits.
+	// It is not supported by streams.
+	//
+	// default: "" (messages are not dead-lettered)
+	DeadLetterExchange string
+
+	// DeadLetterRoutingKey defines the routing-key used when the messages are dead-lettered.
+	// It can only be used with a DeadLetterExchange.
+	//
+	// default: "" (the original message routing-key is used)
+	DeadLetterRoutingKey string
+
+	// DeadLetter defines a dead-letter topology (exchange and queue) to be declared along with the queue.
+	// The dead-letter queue can then be accessed using the queue DeadLetters function.
+	// It can not be used along with the DeadLetterExchange and DeadLetterRoutingKey fields, and it is not supported by streams.
+	//
+	// default: nil (no dead-letter topology is declared)
+	DeadLetter *DeadLetterConfig
+
+	// DeliveryLimit defines how many times a message can be delivered before it is dropped or dead-lettered.
+	// It is only supported by quorum queues.
+	//
+	// default: 0 (the server default is used)
+	DeliveryLimit int
+
+	// When SingleActiveConsumer is set to true, only one consumer receives messages from the queue at a time.
+	// The other consumers are used as fallbacks when the active consumer is cancelled or dies.
+	//
+	// default: false
+	SingleActiveConsumer bool
+
+	// MaxAge defines for how long the messages are retained by a stream.
+	// The messages are removed from the stream in segments, so they could be retained for longer.
+	// It is only supported by streams.
+	//
+	// default: 0 (the messages are retained according to the MaxLengthBytes limit)
+	MaxAge time.Duration
+
+	// StreamMaxSegmentSizeBytes defines the maximum size, in bytes, of each stream segment file on disk.
+	// It is only supported by streams.
+	//
+	// default: 0 (the server default is used)
+	StreamMaxSegmentSizeBytes int64
+
+	// MaxPriority defines the maximum priority the queue supports, making it a priority queue.
+	// Messages are then delivered according to their Priority, set when publishing.
+	// It is only supported by classic queues.
+	//
+	// default: 0 (the queue does not support priorities)
+	MaxPriority uint8
+
 	// When declaring an queue in RabbitMQ, you can include a set of optional arguments to customize its behavior
 	// These arguments are provided as a collection of key-value pairs, where the keys represent specific configuration options,
 	// and the values determine the settings for those options.
+	//
+	// These arguments are used both when declaring the queue and when binding it to the exchange.
 	Args Table
+
+	// BindArgs are optional arguments used only when binding the queue to the exchange, on top of the Args values.
+	// Since they are not used when declaring the queue, the same queue can be bound more than once using different BindArgs.
+	// Headers exchanges, for instance, use these arguments to match the message headers.
+	BindArgs Table
+}
+
+// declareArgs validates the queue configuration and returns the arguments used when declaring the queue,
+// translating the typed fields into their "x-" arguments
+func (c QueueBindConfig) declareArgs() (args Table, err error) {
+	err = c.validate()
+	if err != nil {
+		return
+	}
+
+	typed := Table{}
+	if c.Type != "" {
+		typed["x-queue-type"] = c.Type.ToString()
+	}
+	if c.MessageTTL > 0 {
+		typed["x-message-ttl"] = c.MessageTTL.Milliseconds()
+	}
+	if c.Expires > 0 {
+		typed["x-expires"] = c.Expires.Milliseconds()
+	}
+	if c.MaxLength > 0 {
+		typed["x-max-length"] = int64(c.MaxLength)
+	}
+	if c.MaxLengthBytes > 0 {
+		typed["x-max-length-bytes"] = c.MaxLengthBytes
+	}
+	if c.Overflow != "" {
+		typed["x-overflow"] = c.Overflow.ToString()
+	}
+	if c.DeadLetterExchange != "" {
+		typed["x-dead-letter-exchange"] = c.DeadLetterExchange
+	}
+	if c.DeadLetterRoutingKey != "" {
+		typed["x-dead-letter-routing-key"] = c.DeadLetterRoutingKey
+	}
+	if c.DeliveryLimit > 0 {
+		typed["x-delivery-limit"] = int64(c.DeliveryLimit)
+	}
+	if c.SingleActiveConsumer {
+		typed["x-single-active-consumer"] = true
+	}
+	if c.MaxPriority > 0 {
+		typed["x-max-priority"] = int64(c.MaxPriority)
+	}
+	if c.MaxAge > 0 {
+		typed["x-max-age"] = fmt.Sprintf("%ds", int64(c.MaxAge.Seconds()))
+	}
+	if c.StreamMaxSegmentSizeBytes > 0 {
+		typed["x-stream-max-segment-size-bytes"] = c.StreamMaxSegmentSizeBytes
+	}
+
+	args = Table{}
+	for k, v := range c.Args {
+		args[k] = v
+	}
+	for k, v := range typed {
+		if _, ok := args[k]; ok {
+			err = fmt.Errorf("The %s argument is defined both in the Args and in its typed field", k)
+			return
+		}
+		args[k] = v
+	}
+
+	return
+}
+
+// validate checks the queue configuration for invalid values and incompatible combinations
+func (c QueueBindConfig) validate() error {
+	switch c.Type {
+	case "", QueueTypeClassic, QueueTypeQuorum, QueueTypeStream:
+	default:
+		return fmt.Errorf("Invalid queue type %q", c.Type)
+	}
+
+	switch c.Overflow {
+	case "", QueueOverflowDropHead, QueueOverflowRejectPublish, QueueOverflowRejectPublishDLX:
+	default:
+		return fmt.Errorf("Invalid queue overflow %q", c.Overflow)
+	}
+
+	if c.MessageTTL < 0 || c.Expires < 0 || c.MaxLength < 0 || c.MaxLengthBytes < 0 || c.DeliveryLimit < 0 ||
+		c.MaxAge < 0 || c.StreamMaxSegmentSizeBytes < 0 {
+		return errors.New("The queue TTL, expiration, age, length, size and delivery limits can not be negative")
+	}
+	if c.MaxAge > 0 && c.MaxAge < time.Second {
+		return errors.New("The stream max age must be at least 1 second")
+	}
+	if c.MessageTTL > 0 && c.MessageTTL < time.Millisecond {
+		return errors.New("The queue message TTL must be at least 1 millisecond")
+	}
+	if c.Expires > 0 && c.Expires < time.Millisecond {
+		return errors.New("The queue expiration must be at least 1 millisecond")
+	}
+	_, dlxArg := c.Args["x-dead-letter-exchange"]
+	_, dlrkArg := c.Args["x-dead-letter-routing-key"]
+	if c.DeadLetter != nil && (c.DeadLetterExchange != "" || c.DeadLetterRoutingKey != "" || dlxArg || dlrkArg) {
+		return errors.New("The queue dead-letter topology can not be used along with a dead-letter exchange or routing-key")
+	}
+	if c.DeadLetterRoutingKey != "" && c.DeadLetterExchange == "" {
+		return errors.New("The queue dead-letter routing-key can only be used with a dead-letter exchange")
+	}
+
+	if c.Type == QueueTypeQuorum || c.Type == QueueTypeStream {
+		if !c.Durable || c.Exclusive || c.AutoDelete {
+			return fmt.Errorf("A %s queue must be durable, and can not be exclusive or auto-delete", c.Type)
+		}
+	}
+
+	switch c.Type {
+	case QueueTypeQuorum:
+		if c.MaxPriority > 0 {
+			return errors.New("Quorum queues do not support a max priority")
+		}
+		if c.Overflow == QueueOverflowRejectPublishDLX {
+			return fmt.Errorf("Quorum queues do not support the %q overflow", c.Overflow)
+		}
+	case QueueTypeStream:
+		if c.MessageTTL > 0 || c.Expires > 0 || c.MaxLength > 0 || c.Overflow != "" ||
+			c.DeadLetterExchange != "" || c.DeadLetter != nil || c.DeliveryLimit > 0 || c.MaxPriority > 0 {
+			return errors.New("Streams do not support message TTL, expiration, max length, overflow, dead-lettering, delivery limit or max priority")
+		}
+	default:
+		if c.DeliveryLimit > 0 {
+			return errors.New("The delivery limit is only supported by quorum queues")
+		}
+	}
+
+	if c.Type != QueueTypeStream && (c.MaxAge > 0 || c.StreamMaxSegmentSizeBytes > 0) {
+		return errors.New("The max age and max segment size are only supported by streams")
+	}
+
+	return nil
+}
+
+// bindArgs returns the arguments used when binding the queue to the exchange
+func (c QueueBindConfig) bindArgs() Table {
+	if len(c.BindArgs) == 0 {
+		return c.Args
+	}
+
+	args := Table{}
+	for k, v := range c.Args {
+		args[k] = v
+	}
+	for k, v := range c.BindArgs {
+		args[k] = v
+	}
+
+	return args
 }
@@ -0,0 +1,122 @@
+package amqp
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestQueueBindConfigValidate(t *testing.T) {
+	durable := func(c QueueBindConfig) QueueBindConfig {
+		c.Durable = true
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		config  QueueBindConfig
+		wantErr bool
+	}{
+		{"empty config", QueueBindConfig{}, false},
+		{"invalid type", QueueBindConfig{Type: "lazy"}, true},
+		{"invalid overflow", QueueBindConfig{Overflow: "drop-tail"}, true},
+		{"negative TTL", QueueBindConfig{MessageTTL: -time.Second}, true},
+		{"negative max length", QueueBindConfig{MaxLength: -1}, true},
+		{"TTL below 1 millisecond", QueueBindConfig{MessageTTL: time.Microsecond}, true},
+		{"expiration below 1 millisecond", QueueBindConfig{Expires: time.Microsecond}, true},
+		{"classic queue with limits", QueueBindConfig{MessageTTL: time.Minute, MaxLength: 10, Overflow: QueueOverflowRejectPublishDLX, MaxPriority: 10}, false},
+		{"dead-letter routing-key without exchange", QueueBindConfig{DeadLetterRoutingKey: "dead"}, true},
+		{"dead-letter exchange and routing-key", QueueBindConfig{DeadLetterExchange: "dlx", DeadLetterRoutingKey: "dead"}, false},
+		{"dead-letter topology and exchange", QueueBindConfig{DeadLetter: &DeadLetterConfig{}, DeadLetterExchange: "dlx"}, true},
+		{"dead-letter topology and exchange argument", QueueBindConfig{DeadLetter: &DeadLetterConfig{}, Args: Table{"x-dead-letter-exchange": "dlx"}}, true},
+		{"delivery limit on a classic queue", QueueBindConfig{DeliveryLimit: 3}, true},
+		{"stream max age on a classic queue", QueueBindConfig{MaxAge: time.Hour}, true},
+
+		{"quorum queue", durable(QueueBindConfig{Type: QueueTypeQuorum, DeliveryLimit: 3, DeadLetterExchange: "dlx"}), false},
+		{"quorum queue not durable", QueueBindConfig{Type: QueueTypeQuorum}, true},
+		{"exclusive quorum queue", durable(QueueBindConfig{Type: QueueTypeQuorum, Exclusive: true}), true},
+		{"auto-delete quorum queue", durable(QueueBindConfig{Type: QueueTypeQuorum, AutoDelete: true}), true},
+		{"quorum queue with max priority", durable(QueueBindConfig{Type: QueueTypeQuorum, MaxPriority: 5}), true},
+		{"quorum queue with reject-publish-dlx overflow", durable(QueueBindConfig{Type: QueueTypeQuorum, Overflow: QueueOverflowRejectPublishDLX}), true},
+		{"quorum queue with drop-head overflow", durable(QueueBindConfig{Type: QueueTypeQuorum, Overflow: QueueOverflowDropHead}), false},
+		{"quorum queue with max age", durable(QueueBindConfig{Type: QueueTypeQuorum, MaxAge: time.Hour}), true},
+
+		{"stream", durable(QueueBindConfig{Type: QueueTypeStream, MaxAge: time.Hour, MaxLengthBytes: 1 << 30, StreamMaxSegmentSizeBytes: 1 << 20}), false},
+		{"stream not durable", QueueBindConfig{Type: QueueTypeStream}, true},
+		{"stream with max age below 1 second", durable(QueueBindConfig{Type: QueueTypeStream, MaxAge: time.Millisecond}), true},
+		{"stream with message TTL", durable(QueueBindConfig{Type: QueueTypeStream, MessageTTL: time.Minute}), true},
+		{"stream with expiration", durable(QueueBindConfig{Type: QueueTypeStream, Expires: time.Minute}), true},
+		{"stream with max length", durable(QueueBindConfig{Type: QueueTypeStream, MaxLength: 10}), true},
+		{"stream with overflow", durable(QueueBindConfig{Type: QueueTypeStream, Overflow: QueueOverflowDropHead}), true},
+		{"stream with dead-letter exchange", durable(QueueBindConfig{Type: QueueTypeStream, DeadLetterExchange: "dlx"}), true},
+		{"stream with dead-letter topology", durable(QueueBindConfig{Type: QueueTypeStream, DeadLetter: &DeadLetterConfig{}}), true},
+		{"stream with delivery limit", durable(QueueBindConfig{Type: QueueTypeStream, DeliveryLimit: 3}), true},
+		{"stream with max priority", durable(QueueBindConfig{Type: QueueTypeStream, MaxPriority: 5}), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, want error: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQueueBindConfigDeclareArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  QueueBindConfig
+		want    Table
+		wantErr bool
+	}{
+		{"no arguments", QueueBindConfig{}, Table{}, false},
+		{
+			"typed fields",
+			QueueBindConfig{
+				MessageTTL:           time.Minute,
+				Expires:              time.Hour,
+				MaxLength:            10,
+				Overflow:             QueueOverflowRejectPublish,
+				DeadLetterExchange:   "dlx",
+				DeadLetterRoutingKey: "dead",
+				SingleActiveConsumer: true,
+				MaxPriority:          5,
+				Args:                 Table{"x-custom": "value"},
+			},
+			Table{
+				"x-message-ttl":             int64(60000),
+				"x-expires":                 int64(3600000),
+				"x-max-length":              int64(10),
+				"x-overflow":                "reject-publish",
+				"x-dead-letter-exchange":    "dlx",
+				"x-dead-letter-routing-key": "dead",
+				"x-single-active-consumer":  true,
+				"x-max-priority":            int64(5),
+				"x-custom":                  "value",
+			},
+			false,
+		},
+		{
+			"stream fields",
+			QueueBindConfig{Durable: true, Type: QueueTypeStream, MaxAge: 36 * time.Hour, StreamMaxSegmentSizeBytes: 1024},
+			Table{"x-queue-type": "stream", "x-max-age": "129600s", "x-stream-max-segment-size-bytes": int64(1024)},
+			false,
+		},
+		{"argument defined twice", QueueBindConfig{MessageTTL: time.Minute, Args: Table{"x-message-ttl": 1000}}, nil, true},
+		{"invalid configuration", QueueBindConfig{DeliveryLimit: 3}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.declareArgs()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("declareArgs() error = %v, want error: %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("declareArgs() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
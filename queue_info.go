@@ -0,0 +1,12 @@
+package amqp
+
+// QueueInfo represents the current state of a queue on the server
+type QueueInfo struct {
+	// Name is the queue name
+	Name string
+	// Messages is the number of messages ready to be delivered on the queue.
+	// It does not include the messages that were delivered and are waiting for an acknowledgment.
+	Messages int
+	// Consumers is the number of consumers subscribed to the queue
+	Consumers int
+}
@@ -0,0 +1,46 @@
+package amqp
+
+// QueueType represents a queue type that defines how the queue stores and replicates its messages
+type QueueType string
+
+const (
+	// Classic queues are the original RabbitMQ queue type, stored on a single node.
+	// They support every queue feature, like message priorities, but they are not replicated.
+	QueueTypeClassic = QueueType("classic")
+
+	// Quorum queues are durable, replicated queues based on the Raft consensus algorithm.
+	// They are focused on data safety, and should be used when the messages must not be lost.
+	// Quorum queues must be durable, and can not be exclusive or auto-delete.
+	QueueTypeQuorum = QueueType("quorum")
+
+	// Streams are durable, replicated, append-only logs of messages.
+	// Messages are not removed when consumed, so they can be read many times by different consumers.
+	// Streams must be durable, and can not be exclusive or auto-delete.
+	QueueTypeStream = QueueType("stream")
+)
+
+// ToString returns the string notation of the queue type
+func (t QueueType) ToString() string {
+	return string(t)
+}
+
+// QueueOverflow represents the queue behaviour when its maximum length or size is reached
+type QueueOverflow string
+
+const (
+	// QueueOverflowDropHead drops or dead-letters the oldest messages of the queue to make room for the new ones.
+	QueueOverflowDropHead = QueueOverflow("drop-head")
+
+	// QueueOverflowRejectPublish rejects the new messages published on the queue.
+	// When the publisher is in confirmation mode, the server will nack the message publishing.
+	QueueOverflowRejectPublish = QueueOverflow("reject-publish")
+
+	// QueueOverflowRejectPublishDLX works like QueueOverflowRejectPublish, but also dead-letters the rejected messages.
+	// It is not supported by quorum queues.
+	QueueOverflowRejectPublishDLX = QueueOverflow("reject-publish-dlx")
+)
+
+// ToString returns the string notation of the queue overflow
+func (o QueueOverflow) ToString() string {
+	return string(o)
+}
@@ -0,0 +1,152 @@
+package amqp
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Router dispatches the messages received on a queue to different handler functions,
+// according to the message routing-key, type or headers.
+//
+// The routes are checked in the order they were added, and the message is handled by the first route that matches it.
+// The messages that do not match any route are handled by the fallback handler function.
+//
+// The router Handle function is a HandlerFunc, so it can be used to consume a queue.
+type Router struct {
+	routes   []route
+	fallback HandlerFunc
+}
+
+// route represents a router route, which matches messages and handles them with its handler function
+type route struct {
+	match     func(Delivery) bool
+	handlerFn HandlerFunc
+}
+
+// NewRouter returns a new router without routes
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Route adds a route that matches the messages by their routing-key, using a topic-style pattern.
+//
+// The pattern is made of words separated by dots, where '*' matches exactly one word and '#' matches zero or more words
+// (i.e. "order.*.created" matches "order.123.created", and "order.#" matches "order" and "order.123.created").
+//
+// The provided middlewares are applied only to this route, in the order they were provided.
+func (r *Router) Route(pattern string, handlerFn HandlerFunc, middlewares ...Middleware) {
+	words := topicWords(pattern)
+	r.RouteFunc(func(d Delivery) bool {
+		return matchTopic(words, topicWords(d.RoutingKey))
+	}, handlerFn, middlewares...)
+}
+
+// RouteType adds a route that matches the messages by their type.
+//
+// The provided middlewares are applied only to this route, in the order they were provided.
+func (r *Router) RouteType(msgType string, handlerFn HandlerFunc, middlewares ...Middleware) {
+	r.RouteFunc(func(d Delivery) bool {
+		return d.Type == msgType
+	}, handlerFn, middlewares...)
+}
+
+// RouteHeader adds a route that matches the messages that have a header with the provided value.
+//
+// The provided middlewares are applied only to this route, in the order they were provided.
+func (r *Router) RouteHeader(key string, value any, handlerFn HandlerFunc, middlewares ...Middleware) {
+	r.RouteFunc(func(d Delivery) bool {
+		header, ok := d.Headers[key]
+		return ok && headerEquals(header, value)
+	}, handlerFn, middlewares...)
+}
+
+// RouteFunc adds a route that matches the messages using a custom match function.
+//
+// The provided middlewares are applied only to this route, in the order they were provided.
+func (r *Router) RouteFunc(match func(Delivery) bool, handlerFn HandlerFunc, middlewares ...Middleware) {
+	r.routes = append(r.routes, route{
+		match:     match,
+		handlerFn: chainMiddlewares(handlerFn, middlewares...),
+	})
+}
+
+// Fallback defines the handler function for the messages that do not match any route.
+//
+// When no fallback is defined, the unmatched messages are acknowledged, and handled with an error response.
+func (r *Router) Fallback(handlerFn HandlerFunc, middlewares ...Middleware) {
+	r.fallback = chainMiddlewares(handlerFn, middlewares...)
+}
+
+// Handle dispatches the message to the handler function of the first route that matches it,
+// or to the fallback handler function if no route matches the message
+func (r *Router) Handle(ctx context.Context, d Delivery) HandleResponse {
+	for _, route := range r.routes {
+		if route.match(d) {
+			return route.handlerFn(ctx, d)
+		}
+	}
+
+	if r.fallback != nil {
+		return r.fallback(ctx, d)
+	}
+
+	return HandleResponse{
+		Err: fmt.Errorf("No route matches the message with the %q routing-key and the %q type", d.RoutingKey, d.Type),
+	}
+}
+
+// headerEquals returns if a message header is equal to the expected value.
+//
+// Since the headers numeric types depend on how the message was encoded, the numbers are compared by their value.
+func headerEquals(header, value any) bool {
+	return reflect.DeepEqual(normalizeNumber(header), normalizeNumber(value))
+}
+
+// normalizeNumber converts integer values to int64 and float values to float64
+func normalizeNumber(v any) any {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	default:
+		return v
+	}
+}
+
+// topicWords splits a routing-key or topic pattern in its words.
+// As in the server topic exchanges, an empty routing-key has no words.
+func topicWords(key string) []string {
+	if key == "" {
+		return []string{}
+	}
+
+	return strings.Split(key, ".")
+}
+
+// matchTopic returns if the routing-key words match the topic pattern words,
+// where '*' matches exactly one word and '#' matches zero or more words
+func matchTopic(pattern, key []string) bool {
+	if len(pattern) == 0 {
+		return len(key) == 0
+	}
+
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(key); i++ {
+			if matchTopic(pattern[1:], key[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(key) > 0 && matchTopic(pattern[1:], key[1:])
+	default:
+		return len(key) > 0 && pattern[0] == key[0] && matchTopic(pattern[1:], key[1:])
+	}
+}
@@ -0,0 +1,123 @@
+package amqp
+
+import (
+	"context"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		key     string
+		want    bool
+	}{
+		{"exact match", "order.created", "order.created", true},
+		{"exact mismatch", "order.created", "order.updated", false},
+		{"star matches one word", "order.*.created", "order.123.created", true},
+		{"star does not match zero words", "order.*.created", "order.created", false},
+		{"star does not match two words", "order.*.created", "order.1.2.created", false},
+		{"star does not match an empty key", "*", "", false},
+		{"star matches an empty word", "order.*", "order.", true},
+		{"hash matches zero words", "order.#", "order", true},
+		{"hash matches one word", "order.#", "order.created", true},
+		{"hash matches many words", "order.#", "order.123.created", true},
+		{"hash alone matches an empty key", "#", "", true},
+		{"hash alone matches any key", "#", "a.b.c", true},
+		{"hash in the middle matches zero words", "order.#.created", "order.created", true},
+		{"hash in the middle matches many words", "order.#.created", "order.1.2.created", true},
+		{"hash in the middle needs the suffix", "order.#.created", "order.1.2.updated", false},
+		{"hash followed by star needs one word", "#.*", "", false},
+		{"hash followed by star", "#.*", "order", true},
+		{"empty pattern matches only an empty key", "", "", true},
+		{"empty pattern does not match a key", "", "order", false},
+		{"longer key does not match", "order", "order.created", false},
+		{"longer pattern does not match", "order.created", "order", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchTopic(topicWords(tt.pattern), topicWords(tt.key))
+			if got != tt.want {
+				t.Errorf("matchTopic(%q, %q) = %v, want %v", tt.pattern, tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaderEquals(t *testing.T) {
+	tests := []struct {
+		name   string
+		header any
+		value  any
+		want   bool
+	}{
+		{"same strings", "created", "created", true},
+		{"different strings", "created", "updated", false},
+		{"int32 header and int value", int32(5), 5, true},
+		{"int64 header and uint8 value", int64(5), uint8(5), true},
+		{"different integers", int64(5), 6, false},
+		{"float32 header and float64 value", float32(1.5), 1.5, true},
+		{"integer and float are different types", int64(1), 1.0, false},
+		{"integer and string are different types", 1, "1", false},
+		{"same booleans", true, true, true},
+		{"nil header", nil, "created", false},
+		{"same byte slices", []byte("a"), []byte("a"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := headerEquals(tt.header, tt.value)
+			if got != tt.want {
+				t.Errorf("headerEquals(%#v, %#v) = %v, want %v", tt.header, tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRouterHandle(t *testing.T) {
+	handlerFor := func(name string, handled *string) HandlerFunc {
+		return func(context.Context, Delivery) HandleResponse {
+			*handled = name
+			return HandleResponse{}
+		}
+	}
+
+	tests := []struct {
+		name     string
+		delivery Delivery
+		fallback bool
+		want     string
+		wantErr  bool
+	}{
+		{"first matching route", Delivery{RoutingKey: "order.1.created"}, false, "created", false},
+		{"routes are checked in order", Delivery{RoutingKey: "order.1.created", Type: "order"}, false, "created", false},
+		{"type route", Delivery{RoutingKey: "payment", Type: "order"}, false, "type", false},
+		{"header route", Delivery{Headers: amqp.Table{"version": int32(2)}}, false, "header", false},
+		{"fallback", Delivery{RoutingKey: "payment"}, true, "fallback", false},
+		{"no fallback returns an error", Delivery{RoutingKey: "payment"}, false, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled := ""
+			r := NewRouter()
+			r.Route("order.*.created", handlerFor("created", &handled))
+			r.RouteType("order", handlerFor("type", &handled))
+			r.RouteHeader("version", 2, handlerFor("header", &handled))
+			if tt.fallback {
+				r.Fallback(handlerFor("fallback", &handled))
+			}
+
+			res := r.Handle(context.Background(), tt.delivery)
+			if handled != tt.want {
+				t.Errorf("handled by %q, want %q", handled, tt.want)
+			}
+			if (res.Err != nil) != tt.wantErr {
+				t.Errorf("response error = %v, want error: %v", res.Err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package amqp
+
+import (
+	"time"
+)
+
+// streamOffsetKey is the consume argument used to define where a stream consumer starts reading
+const streamOffsetKey = "x-stream-offset"
+
+// StreamOffset represents where a stream consumer starts reading the stream
+type StreamOffset struct {
+	value any
+}
+
+// StreamOffsetFirst starts reading the stream from the first available message
+func StreamOffsetFirst() StreamOffset {
+	return StreamOffset{"first"}
+}
+
+// StreamOffsetLast starts reading the stream from the last written chunk of messages
+func StreamOffsetLast() StreamOffset {
+	return StreamOffset{"last"}
+}
+
+// StreamOffsetNext starts reading the stream from the next message written after the consumer starts
+func StreamOffsetNext() StreamOffset {
+	return StreamOffset{"next"}
+}
+
+// StreamOffsetAt starts reading the stream from a specific offset
+func StreamOffsetAt(offset int64) StreamOffset {
+	return StreamOffset{offset}
+}
+
+// StreamOffsetTimestamp starts reading the stream from the messages written at or after a specific time
+func StreamOffsetTimestamp(t time.Time) StreamOffset {
+	return StreamOffset{t}
+}
+
+// isSet returns if the stream offset was defined
+func (o StreamOffset) isSet() bool {
+	return o.value != nil
+}
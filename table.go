@@ -1,6 +1,8 @@
 package amqp
 
 import (
+	"reflect"
+
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
@@ -20,3 +22,12 @@ func (t Table) toAmqpTable() amqp.Table {
 
 	return amqp.Table(t)
 }
+
+// sameArgs returns if two argument tables have the same arguments, considering nil and empty tables equal
+func sameArgs(a, b Table) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,177 @@
+package amqp
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name of the tracer used by the library
+const tracerName = "github.com/delivery-much/go-amqp"
+
+// defaultExchangeSpanName is the destination name used in the span names of messages published on the default exchange
+const defaultExchangeSpanName = "amq.default"
+
+// deliveryOutcomeKey is the span attribute with how a consumed message was acknowledged
+const deliveryOutcomeKey = attribute.Key("messaging.rabbitmq.delivery.outcome")
+
+// TracingConfig represents the configuration used to trace the message publishing and handling with OpenTelemetry
+type TracingConfig struct {
+	// TracerProvider is the provider of the tracer used to create the spans.
+	//
+	// default: the global tracer provider (otel.GetTracerProvider())
+	TracerProvider trace.TracerProvider
+
+	// Propagator is used to inject the trace context into the published message headers,
+	// and to extract it from the consumed message headers.
+	//
+	// default: the W3C trace context propagator (propagation.TraceContext{})
+	Propagator propagation.TextMapPropagator
+}
+
+// tracer creates the spans of the message publishing and handling, and propagates the trace context through the message headers
+type tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// newTracer creates a tracer from the tracing configuration, or returns nil if there is no configuration
+func newTracer(conf *TracingConfig) *tracer {
+	if conf == nil {
+		return nil
+	}
+
+	provider := conf.TracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	propagator := conf.Propagator
+	if propagator == nil {
+		propagator = propagation.TraceContext{}
+	}
+
+	return &tracer{
+		tracer:     provider.Tracer(tracerName),
+		propagator: propagator,
+	}
+}
+
+// interceptor returns a publish interceptor that creates a producer span for the publishing,
+// injecting its trace context into the message headers
+func (t *tracer) interceptor() PublishInterceptor {
+	return func(next PublishFunc) PublishFunc {
+		return func(ctx context.Context, msg PublishMessage) error {
+			ctx, span := t.tracer.Start(
+				ctx,
+				spanName(msg.Exchange, "publish"),
+				trace.WithSpanKind(trace.SpanKindProducer),
+				trace.WithAttributes(
+					semconv.MessagingSystem("rabbitmq"),
+					semconv.MessagingOperationPublish,
+					semconv.MessagingDestinationName(msg.Exchange),
+					semconv.MessagingRabbitmqDestinationRoutingKey(msg.RoutingKey),
+					semconv.MessagingMessageID(msg.Config.MessageId),
+					semconv.MessagingMessagePayloadSizeBytes(len(msg.Body)),
+				),
+			)
+			defer span.End()
+
+			carrier := headersCarrier{}
+			t.propagator.Inject(ctx, carrier)
+			for k, v := range carrier {
+				msg.SetHeader(k, v)
+			}
+
+			err := next(ctx, msg)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return err
+		}
+	}
+}
+
+// startConsume extracts the trace context from the message headers,
+// and starts a consumer span for the message handling as its child
+func (t *tracer) startConsume(ctx context.Context, queue string, d Delivery) (context.Context, trace.Span) {
+	if t == nil {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	ctx = t.propagator.Extract(ctx, headersCarrier(d.Headers))
+
+	return t.tracer.Start(
+		ctx,
+		spanName(queue, "process"),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystem("rabbitmq"),
+			semconv.MessagingOperationProcess,
+			semconv.MessagingDestinationName(d.Exchange),
+			semconv.MessagingRabbitmqDestinationRoutingKey(d.RoutingKey),
+			semconv.MessagingMessageID(d.MessageId),
+			semconv.MessagingMessagePayloadSizeBytes(len(d.Body)),
+		),
+	)
+}
+
+// endConsume records the handling result and how the message was acknowledged on the consumer span, and ends it
+func (t *tracer) endConsume(span trace.Span, res HandleResponse, ackErr error) {
+	if t == nil {
+		return
+	}
+
+	span.SetAttributes(deliveryOutcomeKey.String(res.outcome().ToString()))
+
+	err := res.Err
+	if err == nil {
+		err = ackErr
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// spanName returns the span name of a messaging operation on a destination
+func spanName(destination, operation string) string {
+	if destination == "" {
+		destination = defaultExchangeSpanName
+	}
+
+	return destination + " " + operation
+}
+
+// headersCarrier adapts the message headers to a propagation.TextMapCarrier
+type headersCarrier Table
+
+// Get returns the value of a header, or an empty string if it is not a string
+func (c headersCarrier) Get(key string) string {
+	v, _ := c[key].(string)
+	return v
+}
+
+// Set sets the value of a header
+func (c headersCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys returns the keys of the headers
+func (c headersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}